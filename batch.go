@@ -0,0 +1,102 @@
+package todo_list_sber
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MaxBatchOperations bounds the number of operations of a batch.
+const MaxBatchOperations = 100
+
+// Batch operations.
+const (
+	BatchCreate   = "create"
+	BatchUpdate   = "update"
+	BatchDelete   = "delete"
+	BatchComplete = "complete"
+)
+
+// Batch modes: an atomic batch is rolled back as a whole when one of its operations fails, the
+// operations of a partial batch succeed or fail one by one.
+const (
+	BatchAtomic  = "atomic"
+	BatchPartial = "partial"
+)
+
+// Batch is a list of item operations run in order in a single transaction.
+type Batch struct {
+	// Mode is BatchAtomic, the default, or BatchPartial.
+	Mode       string           `json:"mode" enums:"atomic,partial"`
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperation is one operation of a batch. Create takes Item; update takes Id and Patch, a JSON
+// Merge Patch of the ItemFields of the item; delete takes Id; complete takes Id and marks the item
+// done, and its undone subtasks as well with Cascade. Version makes an update or completion fail
+// unless the item is at that version.
+type BatchOperation struct {
+	Op      string          `json:"op" enums:"create,update,delete,complete"`
+	Id      int             `json:"id,omitempty"`
+	Item    *TodoItem       `json:"item,omitempty"`
+	Patch   json.RawMessage `json:"patch,omitempty" swaggertype:"object"`
+	Cascade bool            `json:"cascade,omitempty"`
+	Version *int            `json:"version,omitempty"`
+}
+
+// BatchResult is the outcome of one operation of a batch: the id of the item it worked on, or the
+// error it failed with.
+type BatchResult struct {
+	Id  int
+	Err error
+}
+
+func (b Batch) Validate() error {
+	if b.Mode != "" && b.Mode != BatchAtomic && b.Mode != BatchPartial {
+		return &ValidationError{Message: fmt.Sprintf("mode must be %s or %s", BatchAtomic, BatchPartial)}
+	}
+	if len(b.Operations) == 0 {
+		return &ValidationError{Message: "batch has no operations"}
+	}
+	if len(b.Operations) > MaxBatchOperations {
+		return &ValidationError{Message: fmt.Sprintf("batch must not have more than %d operations", MaxBatchOperations)}
+	}
+	for i, operation := range b.Operations {
+		if err := operation.validate(); err != nil {
+			return &ValidationError{Message: fmt.Sprintf("operation %d: %s", i, err.Error())}
+		}
+	}
+	return nil
+}
+
+// Atomic reports whether the batch is rolled back as a whole when one of its operations fails.
+func (b Batch) Atomic() bool {
+	return b.Mode != BatchPartial
+}
+
+func (o BatchOperation) validate() error {
+	switch o.Op {
+	case BatchCreate:
+		if o.Item == nil {
+			return errors.New("create requires item")
+		}
+		return nil
+	case BatchUpdate:
+		if len(o.Patch) == 0 {
+			return errors.New("update requires patch")
+		}
+	case BatchDelete, BatchComplete:
+	default:
+		return fmt.Errorf("unknown op %q", o.Op)
+	}
+	if o.Id <= 0 {
+		return errors.New(o.Op + " requires id")
+	}
+	return nil
+}
+
+// ErrBatchRolledBack is reported for every operation of an atomic batch other than the one at index
+// failed, whose failure rolled the batch back.
+func ErrBatchRolledBack(failed int) error {
+	return &FailedDependencyError{Message: fmt.Sprintf("batch rolled back because operation %d failed", failed)}
+}
@@ -1,14 +1,32 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
+	// Recurring items are expanded in IANA timezones, which must resolve without a system tz database.
+	_ "time/tzdata"
 	todolistsber "todo-list-sber"
 	_ "todo-list-sber/docs"
+	"todo-list-sber/pkg/config"
 	"todo-list-sber/pkg/handler"
+	"todo-list-sber/pkg/migrate"
+	"todo-list-sber/pkg/notify"
 	"todo-list-sber/pkg/repository"
+	"todo-list-sber/pkg/scheduler"
 	"todo-list-sber/pkg/service"
+	"todo-list-sber/pkg/trash"
+	"todo-list-sber/pkg/webhook"
 )
 
 // @title           Todo List API
@@ -17,32 +35,169 @@ import (
 // @host            localhost:8080
 // @BasePath        /
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+
 func main() {
-	db, err := repository.NewPostgresDB(repository.Config{
-		Host:     "todo-list-postgres",
-		Port:     "5432",
-		Username: "postgres",
-		Password: "postgres",
-		DBName:   "postgres",
-		SSLMode:  "disable",
-	})
-	for {
-		if err := db.Ping(); err == nil {
-			break
+	args := os.Args[1:]
+	isMigrate := len(args) > 0 && args[0] == "migrate"
+	if isMigrate {
+		args = args[1:]
+	}
+	load := config.Load
+	if isMigrate {
+		load = config.LoadMigrate
+	}
+	cfg, args, err := load(args)
+	if err != nil {
+		log.Fatalf("error loading config: %s", err.Error())
+	}
+	setupLogger(cfg.Log)
+
+	// ctx is cancelled on SIGINT/SIGTERM; background work started from main must stop when it is done.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if isMigrate {
+		runMigrate(ctx, cfg.DB, args)
+		return
+	}
+
+	var db *sqlx.DB
+	var repos *repository.Repository
+	if cfg.DB.Driver == repository.DriverMemory {
+		log.Println("Using in-memory storage")
+		repos = repository.NewMemoryRepository()
+	} else {
+		db = connectDB(ctx, cfg.DB)
+		if cfg.DB.MigrateOnStart {
+			migrator, err := migrate.New(db)
+			if err != nil {
+				log.Fatalf("error loading migrations: %s", err.Error())
+			}
+			if err := migrator.Up(ctx); err != nil {
+				log.Fatalf("error applying migrations: %s", err.Error())
+			}
 		}
-		log.Println("Waiting for database to be ready...")
-		time.Sleep(time.Second)
+		repos = repository.NewRepository(db)
 	}
+	services := service.NewService(repos, cfg.Auth, cfg.Events)
+	handlers := handler.NewHandler(services, cfg.DB.QueryTimeout, cfg.Events.PollInterval)
 
-	if err != nil {
-		log.Fatalf("error initialiazing db: %s", err.Error())
+	srv := todolistsber.NewServer(cfg.HTTP, handlers.InitRoutes())
+	srv.RegisterOnShutdown(handlers.CloseStreams)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.Run()
+	}()
+	log.Printf("Todo app started on port %s", cfg.HTTP.Port)
+
+	var background sync.WaitGroup
+	if cfg.Reminders.Enabled {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			newScheduler(repos, cfg.Reminders).Run(ctx)
+		}()
+	}
+	if cfg.Webhooks.Enabled {
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			newDispatcher(repos, cfg.Webhooks).Run(ctx)
+		}()
+	}
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		trash.New(repos.TodoItem, trash.Config{Retention: cfg.Trash.Retention, Interval: cfg.Trash.PurgeInterval}).Run(ctx)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting server: %s", err.Error())
+		}
+	case <-ctx.Done():
+	}
+	stop()
+	log.Println("Todo app shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("error on server shutting down: %s", err.Error())
 	}
-	repos := repository.NewRepository(db)
-	services := service.NewService(repos)
-	handlers := handler.NewHandler(services)
+	background.Wait()
+	if db != nil {
+		if err := db.Close(); err != nil {
+			log.Printf("error on db connection close: %s", err.Error())
+		}
+	}
+}
+
+func newScheduler(repos *repository.Repository, cfg config.RemindersConfig) *scheduler.Scheduler {
+	var notifier notify.Notifier = notify.NewLogNotifier(slog.Default())
+	if cfg.Notifier == "webhook" {
+		notifier = notify.NewWebhookNotifier(cfg.WebhookURL, cfg.WebhookTimeout)
+	}
+	return scheduler.New(repos.Reminder, notifier, scheduler.Config{
+		PollInterval: cfg.PollInterval,
+		Lease:        cfg.Lease,
+		BatchSize:    cfg.BatchSize,
+	})
+}
+
+func newDispatcher(repos *repository.Repository, cfg config.WebhooksConfig) *webhook.Dispatcher {
+	return webhook.NewDispatcher(repos.Webhook, webhook.Config{
+		PollInterval: cfg.PollInterval,
+		Lease:        cfg.Lease,
+		BatchSize:    cfg.BatchSize,
+		Timeout:      cfg.Timeout,
+		MaxAttempts:  cfg.MaxAttempts,
+		BackoffBase:  cfg.BackoffBase,
+		BackoffMax:   cfg.BackoffMax,
+
+		AllowPrivateNetworks: cfg.AllowPrivateNetworks,
+	})
+}
 
-	srv := new(todolistsber.Server)
-	if err := srv.Run("8080", handlers.InitRoutes()); err != nil {
-		log.Fatalf("Error starting server: %s", err.Error())
+func setupLogger(cfg config.LogConfig) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
+
+// connectDB opens the configured database, retrying until it accepts connections or
+// cfg.ConnectTimeout elapses. The Postgres container usually starts after the app.
+func connectDB(ctx context.Context, cfg config.DBConfig) *sqlx.DB {
+	repoCfg := repository.Config{
+		Driver:   cfg.Driver,
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DBName:   cfg.DBName,
+		SSLMode:  cfg.SSLMode,
+		Path:     cfg.Path,
+	}
+	deadline := time.Now().Add(cfg.ConnectTimeout)
+	for {
+		db, err := repository.NewDB(repoCfg)
+		if err == nil {
+			return db
+		}
+		if cfg.Driver != repository.DriverPostgres || time.Now().After(deadline) {
+			log.Fatalf("error initialiazing db: %s", err.Error())
+		}
+		log.Println("Waiting for database to be ready...")
+		select {
+		case <-ctx.Done():
+			log.Fatalf("error initialiazing db: %s", ctx.Err())
+		case <-time.After(time.Second):
+		}
 	}
 }
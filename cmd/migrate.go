@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"todo-list-sber/pkg/config"
+	"todo-list-sber/pkg/migrate"
+	"todo-list-sber/pkg/repository"
+)
+
+const migrateUsage = "usage: main migrate [flags] up | down [N] | status"
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(ctx context.Context, cfg config.DBConfig, args []string) {
+	if cfg.Driver == repository.DriverMemory {
+		log.Fatal("the memory driver has no schema to migrate")
+	}
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	db := connectDB(ctx, cfg)
+	defer db.Close()
+	migrator, err := migrate.New(db)
+	if err != nil {
+		log.Fatalf("error loading migrations: %s", err.Error())
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				log.Fatal(migrateUsage)
+			}
+		}
+		err = migrator.Down(ctx, steps)
+	case "status":
+		var statuses []migrate.Status
+		statuses, err = migrator.Status(ctx)
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied"
+			}
+			fmt.Printf("%06d_%s\t%s\n", status.Version, status.Name, state)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+	if err != nil {
+		log.Fatalf("error running migrations: %s", err.Error())
+	}
+}
@@ -0,0 +1,39 @@
+package todo_list_sber
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// ItemCursor is a position in the (date, id) order of todo items. It is handed to clients
+// as an opaque token, see Encode and DecodeItemCursor.
+type ItemCursor struct {
+	Date time.Time `json:"d"`
+	Id   int       `json:"i"`
+	// Desc records the direction of the listing the cursor was taken from.
+	Desc bool `json:"s,omitempty"`
+	// Backward asks for the page before the position instead of the one after it.
+	Backward bool `json:"b,omitempty"`
+}
+
+// TodoItemCursorPage is one page of a keyset listing. Next and Prev are nil on the last and first page.
+type TodoItemCursorPage struct {
+	Items []TodoItem
+	Next  *ItemCursor
+	Prev  *ItemCursor
+}
+
+func (c ItemCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeItemCursor(token string) (ItemCursor, error) {
+	var cursor ItemCursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.Id <= 0 {
+		return ItemCursor{}, &ValidationError{Message: "invalid cursor"}
+	}
+	return cursor, nil
+}
@@ -0,0 +1,109 @@
+package todo_list_sber
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NotFoundError is returned when the requested entity does not exist.
+type NotFoundError struct {
+	Entity string
+	Id     int
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Id == 0 {
+		return fmt.Sprintf("%s not found", e.Entity)
+	}
+	return fmt.Sprintf("%s with id %d not found", e.Entity, e.Id)
+}
+
+// ValidationError is returned when the input violates a domain rule.
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ConflictError is returned when the request clashes with the current state of the data.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// PreconditionFailedError is returned when a conditional change was based on an outdated state.
+type PreconditionFailedError struct {
+	Message string
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return e.Message
+}
+
+// FailedDependencyError is returned for a change undone because another change made with it failed.
+type FailedDependencyError struct {
+	Message string
+}
+
+func (e *FailedDependencyError) Error() string {
+	return e.Message
+}
+
+// UnauthorizedError is returned when credentials or an access token are missing or invalid.
+type UnauthorizedError struct {
+	Message string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+func ErrTodoItemNotFound(id int) error {
+	return &NotFoundError{Entity: "todo item", Id: id}
+}
+
+func ErrTodoListNotFound(id int) error {
+	return &NotFoundError{Entity: "todo list", Id: id}
+}
+
+func ErrTagNotFound(id int) error {
+	return &NotFoundError{Entity: "tag", Id: id}
+}
+
+func ErrRevisionNotFound(itemId, revision int) error {
+	return &NotFoundError{Entity: fmt.Sprintf("revision %d of todo item %d", revision, itemId)}
+}
+
+func ErrBlockerNotFound(itemId, blockerId int) error {
+	return &NotFoundError{Entity: fmt.Sprintf("blocker %d of todo item %d", blockerId, itemId)}
+}
+
+// ErrVersionMismatch is returned when an item is changed on the basis of a version other than its current one.
+func ErrVersionMismatch(id, version int) error {
+	return &PreconditionFailedError{Message: fmt.Sprintf("todo item %d has been changed, its current version is %d", id, version)}
+}
+
+// ErrItemBlocked is returned when an item is marked done while the items blockerIds are open.
+func ErrItemBlocked(id int, blockerIds []int) error {
+	ids := make([]string, len(blockerIds))
+	for i, blockerId := range blockerIds {
+		ids[i] = strconv.Itoa(blockerId)
+	}
+	return &ConflictError{Message: fmt.Sprintf("todo item %d is blocked by open items %s", id, strings.Join(ids, ", "))}
+}
+
+var (
+	ErrUserNotFound       = &NotFoundError{Entity: "user"}
+	ErrUsernameTaken      = &ConflictError{Message: "username is already taken"}
+	ErrInvalidCredentials = &UnauthorizedError{Message: "invalid username or password"}
+	ErrTagExists          = &ConflictError{Message: "tag with this name already exists"}
+	ErrItemCycle          = &ConflictError{Message: "item cannot be moved under itself or one of its subtasks"}
+	ErrDependencyCycle    = &ConflictError{Message: "item cannot be blocked by itself or by an item it blocks"}
+	ErrParentInTrash      = &ConflictError{Message: "parent item is in the trash, restore it first"}
+)
@@ -0,0 +1,15 @@
+package todo_list_sber
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StoredEvent is an item event kept in the buffer the event stream resumes from. Ids grow with
+// every event, so a client resumes after the id of the last event it has seen.
+type StoredEvent struct {
+	Id        int             `json:"id" db:"id"`
+	Event     string          `json:"event" db:"event"`
+	Payload   json.RawMessage `json:"payload" db:"-" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
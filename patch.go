@@ -0,0 +1,124 @@
+package todo_list_sber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-list-sber/pkg/jsonpatch"
+)
+
+// ItemFields holds every field of a todo item a user can set. Unlike UpdateItemInput it replaces
+// the item as a whole: a field left out gets the value a new item would have, so an item without
+// list_id is taken out of its list.
+type ItemFields struct {
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	IsDone      bool       `json:"is_done"`
+	Date        *time.Time `json:"date"`
+	Priority    int        `json:"priority"`
+	ListId      *int       `json:"list_id"`
+	Tags        []string   `json:"tags"`
+	Recurrence  string     `json:"recurrence"`
+	Timezone    string     `json:"timezone"`
+	// Cascade and Version are passed on to the update, see UpdateItemInput.
+	Cascade bool `json:"-"`
+	Version *int `json:"-"`
+}
+
+// FieldsOf returns the fields of item, the document a patch of the item applies to.
+func FieldsOf(item TodoItem) ItemFields {
+	date := item.Date
+	return ItemFields{
+		Title:       item.Title,
+		Description: item.Description,
+		IsDone:      item.IsDone,
+		Date:        &date,
+		Priority:    item.Priority,
+		ListId:      item.ListId,
+		Tags:        append([]string{}, item.Tags...),
+		Recurrence:  item.Recurrence,
+		Timezone:    item.Timezone,
+	}
+}
+
+func (f ItemFields) Validate() error {
+	if f.Date == nil {
+		return &ValidationError{Message: "date is required"}
+	}
+	item := TodoItem{Title: f.Title, Priority: f.Priority, Tags: f.Tags, Recurrence: f.Recurrence, Timezone: f.Timezone}
+	return item.Validate()
+}
+
+// UpdateInput returns the update setting every field of an item to f. A priority of 0 stands for
+// DefaultPriority, as on create.
+func (f ItemFields) UpdateInput() UpdateItemInput {
+	priority := f.Priority
+	if priority == 0 {
+		priority = DefaultPriority
+	}
+	tags := append([]string{}, f.Tags...)
+	return UpdateItemInput{
+		Title:       &f.Title,
+		Description: &f.Description,
+		IsDone:      &f.IsDone,
+		Date:        f.Date,
+		Priority:    &priority,
+		ListId:      f.ListId,
+		ClearList:   f.ListId == nil,
+		Tags:        &tags,
+		Recurrence:  &f.Recurrence,
+		Timezone:    &f.Timezone,
+		Cascade:     f.Cascade,
+		Version:     f.Version,
+	}
+}
+
+// Patch formats, named after the media types of their documents.
+const (
+	// MergePatch is a JSON Merge Patch (RFC 7396), sent as application/merge-patch+json.
+	MergePatch = "merge-patch"
+	// JSONPatch is a JSON Patch (RFC 6902), sent as application/json-patch+json.
+	JSONPatch = "json-patch"
+)
+
+// ItemPatch is a patch document in Format applied to the ItemFields of a todo item.
+type ItemPatch struct {
+	Format   string
+	Document []byte
+	Cascade  bool
+	// Version, when set, makes the patch fail with ErrVersionMismatch unless the item is at that version.
+	Version *int
+}
+
+// Apply returns fields with the patch applied. A patch that does not apply, or whose result is not
+// a valid set of fields, fails with a ValidationError; a failed JSON Patch test with a ConflictError.
+func (p ItemPatch) Apply(fields ItemFields) (ItemFields, error) {
+	doc, err := json.Marshal(fields)
+	if err != nil {
+		return fields, err
+	}
+	switch p.Format {
+	case MergePatch:
+		doc, err = jsonpatch.MergePatch(doc, p.Document)
+	case JSONPatch:
+		doc, err = jsonpatch.Apply(doc, p.Document)
+	default:
+		return fields, &ValidationError{Message: "unknown patch format " + p.Format}
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return fields, &ConflictError{Message: err.Error()}
+	}
+	if err != nil {
+		return fields, &ValidationError{Message: err.Error()}
+	}
+
+	var patched ItemFields
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return fields, &ValidationError{Message: "invalid patched item: " + err.Error()}
+	}
+	patched.Cascade, patched.Version = fields.Cascade, fields.Version
+	return patched, nil
+}
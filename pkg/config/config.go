@@ -0,0 +1,557 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultPath = "configs/config.yml"
+
+// minSigningKeyLength is the shortest HMAC key accepted for signing access tokens.
+const minSigningKeyLength = 32
+
+// placeholderSigningKey is the example key earlier versions of docker-compose.yml shipped with. It
+// is public, so tokens signed with it can be forged by anyone.
+const placeholderSigningKey = "change-me-to-a-random-32-byte-secret"
+
+type Config struct {
+	HTTP HTTPConfig `yaml:"http"`
+	DB   DBConfig   `yaml:"db"`
+	Auth AuthConfig `yaml:"auth"`
+	Log  LogConfig  `yaml:"log"`
+
+	Reminders RemindersConfig `yaml:"reminders"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	Events    EventsConfig    `yaml:"events"`
+	Trash     TrashConfig     `yaml:"trash"`
+}
+
+type HTTPConfig struct {
+	Port           string        `yaml:"port"`
+	ReadTimeout    time.Duration `yaml:"read_timeout"`
+	WriteTimeout   time.Duration `yaml:"write_timeout"`
+	MaxHeaderBytes int           `yaml:"max_header_bytes"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish after a shutdown signal.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DBConfig struct {
+	Driver   string `yaml:"driver"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// PasswordFile points to a file holding the password, e.g. a docker secret. It takes precedence over Password.
+	PasswordFile   string        `yaml:"password_file"`
+	DBName         string        `yaml:"dbname"`
+	SSLMode        string        `yaml:"sslmode"`
+	Path           string        `yaml:"path"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+	// QueryTimeout is the deadline for the database work done on behalf of one API request; 0 disables it.
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// MigrateOnStart applies pending schema migrations before the server starts.
+	MigrateOnStart bool `yaml:"migrate_on_start"`
+}
+
+type AuthConfig struct {
+	// SigningKey is the HMAC secret for access tokens. Prefer SigningKeyFile, which takes precedence.
+	SigningKey     string        `yaml:"signing_key"`
+	SigningKeyFile string        `yaml:"signing_key_file"`
+	TokenTTL       time.Duration `yaml:"token_ttl"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+type RemindersConfig struct {
+	// Enabled runs the background scheduler delivering due reminders.
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// Lease is how long a reminder is reserved for one delivery attempt before it is retried.
+	Lease     time.Duration `yaml:"lease"`
+	BatchSize int           `yaml:"batch_size"`
+	// Notifier is "log" or "webhook"; the webhook notifier posts every reminder to WebhookURL.
+	Notifier       string        `yaml:"notifier"`
+	WebhookURL     string        `yaml:"webhook_url"`
+	WebhookTimeout time.Duration `yaml:"webhook_timeout"`
+}
+
+type WebhooksConfig struct {
+	// Enabled runs the background dispatcher sending queued item events to webhooks.
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+	Lease        time.Duration `yaml:"lease"`
+	BatchSize    int           `yaml:"batch_size"`
+	Timeout      time.Duration `yaml:"timeout"`
+	// A delivery is attempted MaxAttempts times; the n-th retry waits BackoffBase * 2^(n-1), capped at BackoffMax.
+	MaxAttempts int           `yaml:"max_attempts"`
+	BackoffBase time.Duration `yaml:"backoff_base"`
+	BackoffMax  time.Duration `yaml:"backoff_max"`
+	// AllowPrivateNetworks lets webhooks reach loopback, private and link-local addresses.
+	AllowPrivateNetworks bool `yaml:"allow_private_networks"`
+}
+
+type EventsConfig struct {
+	// BufferSize is how many of the latest item events of every user are kept for the event stream to resume from.
+	BufferSize int `yaml:"buffer_size"`
+	// PollInterval is how often an event stream looks for events published by other server instances.
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+type TrashConfig struct {
+	// Retention is how long deleted items stay in the trash before they are purged for good.
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+func defaults() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Port:            "8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    10 * time.Second,
+			MaxHeaderBytes:  1 << 20,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		DB: DBConfig{
+			Driver:         "postgres",
+			Host:           "localhost",
+			Port:           "5432",
+			Username:       "postgres",
+			DBName:         "postgres",
+			SSLMode:        "disable",
+			Path:           "todo.db",
+			ConnectTimeout: 30 * time.Second,
+			QueryTimeout:   5 * time.Second,
+		},
+		Auth: AuthConfig{
+			TokenTTL: 12 * time.Hour,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Reminders: RemindersConfig{
+			Enabled:        true,
+			PollInterval:   30 * time.Second,
+			Lease:          time.Minute,
+			BatchSize:      100,
+			Notifier:       "log",
+			WebhookTimeout: 10 * time.Second,
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:      true,
+			PollInterval: 5 * time.Second,
+			Lease:        time.Minute,
+			BatchSize:    100,
+			Timeout:      10 * time.Second,
+			MaxAttempts:  8,
+			BackoffBase:  30 * time.Second,
+			BackoffMax:   time.Hour,
+		},
+		Events: EventsConfig{
+			BufferSize:   1000,
+			PollInterval: 5 * time.Second,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// Load builds the configuration from, in increasing order of precedence: built-in defaults,
+// the YAML config file, environment variables and command line flags. Secrets referenced
+// by *_file settings are read last and the result is validated. The arguments left after
+// the flags are returned alongside the configuration.
+func Load(args []string) (*Config, []string, error) {
+	cfg, rest, err := load(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, rest, nil
+}
+
+// LoadMigrate builds the configuration like Load for the migrate command, which only validates the
+// settings it uses: the database and the log.
+func LoadMigrate(args []string) (*Config, []string, error) {
+	cfg, rest, err := load(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := joinErrors(append(cfg.validateDB(), cfg.validateLog()...)); err != nil {
+		return nil, nil, err
+	}
+	return cfg, rest, nil
+}
+
+func load(args []string) (*Config, []string, error) {
+	cfg := defaults()
+
+	fs := flag.NewFlagSet("todo-list", flag.ContinueOnError)
+	path := fs.String("config", "", "path to the YAML config file (env CONFIG_PATH)")
+	overrides := registerFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := cfg.loadFile(*path); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if apply, ok := overrides[f.Name]; ok {
+			apply(&cfg)
+		}
+	})
+	if err := cfg.readSecrets(); err != nil {
+		return nil, nil, err
+	}
+	return &cfg, fs.Args(), nil
+}
+
+func (c *Config) loadFile(path string) error {
+	explicit := true
+	if path == "" {
+		path = os.Getenv("CONFIG_PATH")
+	}
+	if path == "" {
+		path, explicit = defaultPath, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	setString := func(key string, dst *string) {
+		if v, ok := os.LookupEnv(key); ok {
+			*dst = v
+		}
+	}
+	setDuration := func(key string, dst *time.Duration) error {
+		v, ok := os.LookupEnv(key)
+		if !ok {
+			return nil
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", key, err)
+		}
+		*dst = d
+		return nil
+	}
+
+	setString("HTTP_PORT", &c.HTTP.Port)
+	setString("DB_DRIVER", &c.DB.Driver)
+	// DB_SERVER is the name docker-compose uses for the database host.
+	setString("DB_SERVER", &c.DB.Host)
+	setString("DB_HOST", &c.DB.Host)
+	setString("DB_PORT", &c.DB.Port)
+	setString("DB_USER", &c.DB.Username)
+	setString("DB_PASSWORD", &c.DB.Password)
+	setString("DB_PASSWORD_FILE", &c.DB.PasswordFile)
+	setString("DB_NAME", &c.DB.DBName)
+	setString("DB_SSLMODE", &c.DB.SSLMode)
+	setString("DB_PATH", &c.DB.Path)
+	setString("AUTH_SIGNING_KEY", &c.Auth.SigningKey)
+	setString("AUTH_SIGNING_KEY_FILE", &c.Auth.SigningKeyFile)
+	setString("LOG_LEVEL", &c.Log.Level)
+	setString("REMINDERS_NOTIFIER", &c.Reminders.Notifier)
+	setString("REMINDERS_WEBHOOK_URL", &c.Reminders.WebhookURL)
+
+	if v, ok := os.LookupEnv("HTTP_MAX_HEADER_BYTES"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP_MAX_HEADER_BYTES: %w", err)
+		}
+		c.HTTP.MaxHeaderBytes = n
+	}
+	if v, ok := os.LookupEnv("DB_MIGRATE_ON_START"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid DB_MIGRATE_ON_START: %w", err)
+		}
+		c.DB.MigrateOnStart = b
+	}
+	if v, ok := os.LookupEnv("REMINDERS_ENABLED"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid REMINDERS_ENABLED: %w", err)
+		}
+		c.Reminders.Enabled = b
+	}
+	if v, ok := os.LookupEnv("WEBHOOKS_ENABLED"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOKS_ENABLED: %w", err)
+		}
+		c.Webhooks.Enabled = b
+	}
+	if v, ok := os.LookupEnv("WEBHOOKS_ALLOW_PRIVATE_NETWORKS"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOKS_ALLOW_PRIVATE_NETWORKS: %w", err)
+		}
+		c.Webhooks.AllowPrivateNetworks = b
+	}
+	if err := setDuration("REMINDERS_POLL_INTERVAL", &c.Reminders.PollInterval); err != nil {
+		return err
+	}
+	if err := setDuration("TRASH_RETENTION", &c.Trash.Retention); err != nil {
+		return err
+	}
+	if err := setDuration("TRASH_PURGE_INTERVAL", &c.Trash.PurgeInterval); err != nil {
+		return err
+	}
+	if err := setDuration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout); err != nil {
+		return err
+	}
+	if err := setDuration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout); err != nil {
+		return err
+	}
+	if err := setDuration("HTTP_SHUTDOWN_TIMEOUT", &c.HTTP.ShutdownTimeout); err != nil {
+		return err
+	}
+	if err := setDuration("AUTH_TOKEN_TTL", &c.Auth.TokenTTL); err != nil {
+		return err
+	}
+	if err := setDuration("DB_CONNECT_TIMEOUT", &c.DB.ConnectTimeout); err != nil {
+		return err
+	}
+	return setDuration("DB_QUERY_TIMEOUT", &c.DB.QueryTimeout)
+}
+
+// registerFlags declares the command line flags on fs and returns, for each flag name,
+// a function copying the parsed value into a Config. Only flags present on the
+// command line are applied, so unset flags never clobber file or environment values.
+func registerFlags(fs *flag.FlagSet) map[string]func(*Config) {
+	port := fs.String("port", "", "HTTP port")
+	readTimeout := fs.Duration("read-timeout", 0, "HTTP read timeout")
+	writeTimeout := fs.Duration("write-timeout", 0, "HTTP write timeout")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 0, "time allowed for in-flight requests to finish on shutdown")
+	driver := fs.String("db-driver", "", "database driver: postgres, sqlite3 or memory")
+	host := fs.String("db-host", "", "database host")
+	dbPort := fs.String("db-port", "", "database port")
+	user := fs.String("db-user", "", "database user")
+	passwordFile := fs.String("db-password-file", "", "file containing the database password")
+	dbName := fs.String("db-name", "", "database name")
+	sslMode := fs.String("db-sslmode", "", "database SSL mode")
+	dbPath := fs.String("db-path", "", "SQLite database file")
+	queryTimeout := fs.Duration("query-timeout", 0, "deadline for the database work of one API request, 0 disables it")
+	migrateOnStart := fs.Bool("migrate", false, "apply pending schema migrations on start")
+	signingKeyFile := fs.String("auth-signing-key-file", "", "file containing the access token signing key")
+	tokenTTL := fs.Duration("auth-token-ttl", 0, "access token lifetime")
+	logLevel := fs.String("log-level", "", "log level: debug, info, warn or error")
+
+	return map[string]func(*Config){
+		"port":                  func(c *Config) { c.HTTP.Port = *port },
+		"read-timeout":          func(c *Config) { c.HTTP.ReadTimeout = *readTimeout },
+		"write-timeout":         func(c *Config) { c.HTTP.WriteTimeout = *writeTimeout },
+		"shutdown-timeout":      func(c *Config) { c.HTTP.ShutdownTimeout = *shutdownTimeout },
+		"db-driver":             func(c *Config) { c.DB.Driver = *driver },
+		"db-host":               func(c *Config) { c.DB.Host = *host },
+		"db-port":               func(c *Config) { c.DB.Port = *dbPort },
+		"db-user":               func(c *Config) { c.DB.Username = *user },
+		"db-password-file":      func(c *Config) { c.DB.PasswordFile = *passwordFile },
+		"db-name":               func(c *Config) { c.DB.DBName = *dbName },
+		"db-sslmode":            func(c *Config) { c.DB.SSLMode = *sslMode },
+		"db-path":               func(c *Config) { c.DB.Path = *dbPath },
+		"query-timeout":         func(c *Config) { c.DB.QueryTimeout = *queryTimeout },
+		"migrate":               func(c *Config) { c.DB.MigrateOnStart = *migrateOnStart },
+		"auth-signing-key-file": func(c *Config) { c.Auth.SigningKeyFile = *signingKeyFile },
+		"auth-token-ttl":        func(c *Config) { c.Auth.TokenTTL = *tokenTTL },
+		"log-level":             func(c *Config) { c.Log.Level = *logLevel },
+	}
+}
+
+func (c *Config) readSecrets() error {
+	if c.DB.PasswordFile != "" {
+		data, err := os.ReadFile(c.DB.PasswordFile)
+		if err != nil {
+			return fmt.Errorf("error reading database password file: %w", err)
+		}
+		c.DB.Password = strings.TrimSpace(string(data))
+	}
+	if c.Auth.SigningKeyFile != "" {
+		data, err := os.ReadFile(c.Auth.SigningKeyFile)
+		if err != nil {
+			return fmt.Errorf("error reading signing key file: %w", err)
+		}
+		c.Auth.SigningKey = strings.TrimSpace(string(data))
+	}
+	return nil
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+
+	if port, err := strconv.Atoi(c.HTTP.Port); err != nil || port <= 0 || port > 65535 {
+		errs = append(errs, fmt.Errorf("http.port must be a number between 1 and 65535, got %q", c.HTTP.Port))
+	}
+	if c.HTTP.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("http.read_timeout must be positive"))
+	}
+	if c.HTTP.WriteTimeout <= 0 {
+		errs = append(errs, errors.New("http.write_timeout must be positive"))
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("http.shutdown_timeout must be positive"))
+	}
+	if c.HTTP.MaxHeaderBytes <= 0 {
+		errs = append(errs, errors.New("http.max_header_bytes must be positive"))
+	}
+
+	errs = append(errs, c.validateDB()...)
+
+	if len(c.Auth.SigningKey) < minSigningKeyLength {
+		errs = append(errs, fmt.Errorf("auth.signing_key must be at least %d bytes long", minSigningKeyLength))
+	} else if c.Auth.SigningKey == placeholderSigningKey {
+		errs = append(errs, errors.New("auth.signing_key must be a random secret, not the placeholder value"))
+	}
+	if c.Auth.TokenTTL <= 0 {
+		errs = append(errs, errors.New("auth.token_ttl must be positive"))
+	}
+
+	errs = append(errs, c.validateLog()...)
+
+	if c.Reminders.Enabled {
+		if c.Reminders.PollInterval <= 0 {
+			errs = append(errs, errors.New("reminders.poll_interval must be positive"))
+		}
+		if c.Reminders.Lease <= 0 {
+			errs = append(errs, errors.New("reminders.lease must be positive"))
+		}
+		if c.Reminders.BatchSize <= 0 {
+			errs = append(errs, errors.New("reminders.batch_size must be positive"))
+		}
+		switch c.Reminders.Notifier {
+		case "log":
+		case "webhook":
+			if u, err := url.Parse(c.Reminders.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, errors.New("reminders.webhook_url must be an http or https URL for the webhook notifier"))
+			}
+			if c.Reminders.WebhookTimeout <= 0 {
+				errs = append(errs, errors.New("reminders.webhook_timeout must be positive"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("reminders.notifier must be one of log, webhook, got %q", c.Reminders.Notifier))
+		}
+	}
+
+	if c.Webhooks.Enabled {
+		if c.Webhooks.PollInterval <= 0 {
+			errs = append(errs, errors.New("webhooks.poll_interval must be positive"))
+		}
+		if c.Webhooks.Lease <= 0 {
+			errs = append(errs, errors.New("webhooks.lease must be positive"))
+		}
+		if c.Webhooks.BatchSize <= 0 {
+			errs = append(errs, errors.New("webhooks.batch_size must be positive"))
+		}
+		if c.Webhooks.Timeout <= 0 {
+			errs = append(errs, errors.New("webhooks.timeout must be positive"))
+		}
+		if c.Webhooks.Lease <= c.Webhooks.Timeout {
+			errs = append(errs, errors.New("webhooks.lease must be longer than webhooks.timeout"))
+		}
+		if c.Webhooks.MaxAttempts <= 0 {
+			errs = append(errs, errors.New("webhooks.max_attempts must be positive"))
+		}
+		if c.Webhooks.BackoffBase <= 0 || c.Webhooks.BackoffMax < c.Webhooks.BackoffBase {
+			errs = append(errs, errors.New("webhooks.backoff_base must be positive and not above webhooks.backoff_max"))
+		}
+	}
+
+	if c.Events.BufferSize <= 0 {
+		errs = append(errs, errors.New("events.buffer_size must be positive"))
+	}
+	if c.Events.PollInterval <= 0 {
+		errs = append(errs, errors.New("events.poll_interval must be positive"))
+	}
+
+	if c.Trash.Retention <= 0 {
+		errs = append(errs, errors.New("trash.retention must be positive"))
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("trash.purge_interval must be positive"))
+	}
+
+	return joinErrors(errs)
+}
+
+func (c *Config) validateDB() []error {
+	var errs []error
+
+	switch c.DB.Driver {
+	case "postgres":
+		if c.DB.Host == "" {
+			errs = append(errs, errors.New("db.host is required for postgres"))
+		}
+		if c.DB.Username == "" {
+			errs = append(errs, errors.New("db.username is required for postgres"))
+		}
+		if c.DB.DBName == "" {
+			errs = append(errs, errors.New("db.dbname is required for postgres"))
+		}
+		switch c.DB.SSLMode {
+		case "disable", "require", "verify-ca", "verify-full":
+		default:
+			errs = append(errs, fmt.Errorf("db.sslmode %q is not supported", c.DB.SSLMode))
+		}
+		if c.DB.ConnectTimeout <= 0 {
+			errs = append(errs, errors.New("db.connect_timeout must be positive"))
+		}
+	case "sqlite3":
+		if c.DB.Path == "" {
+			errs = append(errs, errors.New("db.path is required for sqlite3"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("db.driver must be one of postgres, sqlite3, memory, got %q", c.DB.Driver))
+	}
+
+	if c.DB.QueryTimeout < 0 {
+		errs = append(errs, errors.New("db.query_timeout must not be negative"))
+	}
+	return errs
+}
+
+func (c *Config) validateLog() []error {
+	var errs []error
+
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level must be one of debug, info, warn, error, got %q", c.Log.Level))
+	}
+	return errs
+}
+
+func joinErrors(errs []error) error {
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
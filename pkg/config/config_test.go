@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSigningKey satisfies the signing key validation in tests that do not exercise it.
+const testSigningKey = "0123456789abcdef0123456789abcdef"
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("error writing %s: %s", name, err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yml", `
+http:
+  port: "9000"
+  read_timeout: 5s
+db:
+  host: file-host
+  dbname: todos
+log:
+  level: warn
+`)
+	t.Setenv("AUTH_SIGNING_KEY", testSigningKey)
+	t.Setenv("DB_SERVER", "env-host")
+	t.Setenv("LOG_LEVEL", "error")
+
+	cfg, _, err := Load([]string{"-config", path, "-log-level", "debug"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cfg.HTTP.Port != "9000" {
+		t.Errorf("expected port from file; got %q", cfg.HTTP.Port)
+	}
+	if cfg.HTTP.ReadTimeout != 5*time.Second {
+		t.Errorf("expected read timeout from file; got %s", cfg.HTTP.ReadTimeout)
+	}
+	if cfg.HTTP.WriteTimeout != 10*time.Second {
+		t.Errorf("expected default write timeout; got %s", cfg.HTTP.WriteTimeout)
+	}
+	if cfg.DB.Host != "env-host" {
+		t.Errorf("expected host from DB_SERVER; got %q", cfg.DB.Host)
+	}
+	if cfg.DB.DBName != "todos" {
+		t.Errorf("expected dbname from file; got %q", cfg.DB.DBName)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("expected log level from flag; got %q", cfg.Log.Level)
+	}
+}
+
+func TestLoadPasswordFile(t *testing.T) {
+	secret := writeFile(t, "db_password", "s3cret\n")
+	t.Setenv("CONFIG_PATH", writeFile(t, "config.yml", "db:\n  password: from-file\n"))
+	t.Setenv("DB_PASSWORD_FILE", secret)
+	t.Setenv("AUTH_SIGNING_KEY_FILE", writeFile(t, "signing_key", testSigningKey+"\n"))
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if cfg.DB.Password != "s3cret" {
+		t.Errorf("expected password from secret file; got %q", cfg.DB.Password)
+	}
+	if cfg.Auth.SigningKey != testSigningKey {
+		t.Errorf("expected signing key from secret file; got %q", cfg.Auth.SigningKey)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		env           map[string]string
+		expectedError string
+	}{
+		{
+			name:          "Missing Config File",
+			args:          []string{"-config", "does-not-exist.yml"},
+			expectedError: "error reading config file",
+		},
+		{
+			name:          "Invalid Port",
+			args:          []string{"-port", "http"},
+			expectedError: "http.port must be a number",
+		},
+		{
+			name:          "Invalid Driver",
+			env:           map[string]string{"DB_DRIVER": "mysql"},
+			expectedError: "db.driver must be one of",
+		},
+		{
+			name:          "Invalid Timeout",
+			env:           map[string]string{"HTTP_WRITE_TIMEOUT": "soon"},
+			expectedError: "invalid HTTP_WRITE_TIMEOUT",
+		},
+		{
+			name:          "Invalid Log Level",
+			args:          []string{"-log-level", "verbose"},
+			expectedError: "log.level must be one of",
+		},
+		{
+			name:          "Short Signing Key",
+			env:           map[string]string{"AUTH_SIGNING_KEY": "secret"},
+			expectedError: "auth.signing_key must be at least 32 bytes long",
+		},
+		{
+			name:          "Placeholder Signing Key",
+			env:           map[string]string{"AUTH_SIGNING_KEY": "change-me-to-a-random-32-byte-secret"},
+			expectedError: "auth.signing_key must be a random secret",
+		},
+		{
+			name:          "Missing Password File",
+			env:           map[string]string{"DB_PASSWORD_FILE": "does-not-exist"},
+			expectedError: "error reading database password file",
+		},
+		{
+			name:          "Webhook Notifier Without URL",
+			env:           map[string]string{"REMINDERS_NOTIFIER": "webhook"},
+			expectedError: "reminders.webhook_url must be an http or https URL",
+		},
+		{
+			name:          "Invalid Webhooks Enabled",
+			env:           map[string]string{"WEBHOOKS_ENABLED": "sometimes"},
+			expectedError: "invalid WEBHOOKS_ENABLED",
+		},
+		{
+			name:          "Zero Trash Retention",
+			env:           map[string]string{"TRASH_RETENTION": "0s"},
+			expectedError: "trash.retention must be positive",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("CONFIG_PATH", writeFile(t, "config.yml", ""))
+			t.Setenv("AUTH_SIGNING_KEY", testSigningKey)
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+
+			_, _, err := Load(test.args)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("expected error containing %q; got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestLoadMigrate(t *testing.T) {
+	t.Setenv("CONFIG_PATH", writeFile(t, "config.yml", ""))
+	t.Setenv("AUTH_SIGNING_KEY", "")
+	t.Setenv("DB_DRIVER", "sqlite3")
+
+	cfg, args, err := LoadMigrate([]string{"-db-path", "todo.db", "up"})
+	if err != nil {
+		t.Fatalf("expected no error without a signing key; got %v", err)
+	}
+	if cfg.DB.Path != "todo.db" || len(args) != 1 || args[0] != "up" {
+		t.Errorf("unexpected config %+v and args %v", cfg.DB, args)
+	}
+
+	t.Setenv("DB_DRIVER", "oracle")
+	if _, _, err := LoadMigrate(nil); err == nil || !strings.Contains(err.Error(), "db.driver must be one of") {
+		t.Errorf("expected a db.driver error; got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	todoListSber "todo-list-sber"
+)
+
+// @Summary signUp
+// @Tags auth
+// @Description create account
+// @ID create-account
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.User true "account info"
+// @Success 200 {integer} integer 1
+// @Failure 400,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/sign-up [post]
+func (h *Handler) signUp(c *gin.Context) {
+	var input todoListSber.User
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	id, err := h.services.Authorization.CreateUser(c.Request.Context(), input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+type signInInput struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// @Summary signIn
+// @Tags auth
+// @Description get an access token
+// @ID login
+// @Accept  json
+// @Produce  json
+// @Param input body signInInput true "credentials"
+// @Success 200 {string} string "token"
+// @Failure 400,401 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /auth/sign-in [post]
+func (h *Handler) signIn(c *gin.Context) {
+	var input signInInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	token, err := h.services.Authorization.GenerateToken(c.Request.Context(), input.Username, input.Password)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
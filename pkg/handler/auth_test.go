@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestSignUpHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockAuthorization)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"username": "alice", "password": "qwerty123"}`,
+			mockBehavior: func(r *servicemocks.MockAuthorization) {
+				r.EXPECT().CreateUser(gomock.Any(), todoListSber.User{Username: "alice", Password: "qwerty123"}).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:                 "Wrong Input",
+			inputBody:            `{"username": "alice"}`,
+			mockBehavior:         func(r *servicemocks.MockAuthorization) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Username Taken",
+			inputBody: `{"username": "alice", "password": "qwerty123"}`,
+			mockBehavior: func(r *servicemocks.MockAuthorization) {
+				r.EXPECT().CreateUser(gomock.Any(), todoListSber.User{Username: "alice", Password: "qwerty123"}).Return(0, todoListSber.ErrUsernameTaken)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"username is already taken"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuth := servicemocks.NewMockAuthorization(ctrl)
+			test.mockBehavior(mockAuth)
+
+			services := &service.Service{Authorization: mockAuth}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.POST("/auth/sign-up", handler.signUp)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/sign-up", bytes.NewBufferString(test.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSignInHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockAuthorization)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"username": "alice", "password": "qwerty123"}`,
+			mockBehavior: func(r *servicemocks.MockAuthorization) {
+				r.EXPECT().GenerateToken(gomock.Any(), "alice", "qwerty123").Return("token", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"token":"token"}`,
+		},
+		{
+			name:      "Invalid Credentials",
+			inputBody: `{"username": "alice", "password": "wrong"}`,
+			mockBehavior: func(r *servicemocks.MockAuthorization) {
+				r.EXPECT().GenerateToken(gomock.Any(), "alice", "wrong").Return("", todoListSber.ErrInvalidCredentials)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid username or password"}`,
+		},
+		{
+			name:      "Service Error",
+			inputBody: `{"username": "alice", "password": "qwerty123"}`,
+			mockBehavior: func(r *servicemocks.MockAuthorization) {
+				r.EXPECT().GenerateToken(gomock.Any(), "alice", "qwerty123").Return("", errors.New("Service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuth := servicemocks.NewMockAuthorization(ctrl)
+			test.mockBehavior(mockAuth)
+
+			services := &service.Service{Authorization: mockAuth}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.POST("/auth/sign-in", handler.signIn)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/sign-in", bytes.NewBufferString(test.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	todoListSber "todo-list-sber"
+)
+
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// batchResult carries the status code and error message the operation would have been answered
+// with on its own.
+type batchResult struct {
+	Status int    `json:"status"`
+	Id     int    `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// @Security ApiKeyAuth
+// @Summary runBatch
+// @Description run up to 100 create, update (a JSON Merge Patch of the item), delete and complete operations in order in a single transaction, with a result for each. An atomic batch (the default) is rolled back as a whole when an operation fails: it is answered with the status of that operation and the other operations report 424. In a partial batch every operation succeeds or fails on its own and the answer is 200
+// @ID run-batch
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.Batch true "operations"
+// @Success 200 {object} batchResponse
+// @Failure 400,404,409,412 {object} batchResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/batch [post]
+func (h *Handler) runBatch(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var batch todoListSber.Batch
+	if err := c.ShouldBindJSON(&batch); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	results, err := h.services.Batch.Run(c.Request.Context(), userId, batch)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	response := batchResponse{Results: make([]batchResult, len(results))}
+	for i, result := range results {
+		response.Results[i] = batchResult{Status: http.StatusOK, Id: result.Id}
+		if result.Err == nil {
+			continue
+		}
+		response.Results[i].Status = errorStatusCode(result.Err)
+		logError(c, response.Results[i].Status, result.Err.Error())
+		response.Results[i].Error = errorMessage(response.Results[i].Status, result.Err)
+		if batch.Atomic() && response.Results[i].Status != http.StatusFailedDependency {
+			status = response.Results[i].Status
+		}
+	}
+	c.JSON(status, response)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestRunBatchHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockBatch)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"operations": [{"op": "complete", "id": 1, "cascade": true}, {"op": "delete", "id": 2}]}`,
+			mockBehavior: func(r *servicemocks.MockBatch) {
+				batch := todoListSber.Batch{Operations: []todoListSber.BatchOperation{
+					{Op: todoListSber.BatchComplete, Id: 1, Cascade: true},
+					{Op: todoListSber.BatchDelete, Id: 2},
+				}}
+				r.EXPECT().Run(gomock.Any(), 1, batch).Return([]todoListSber.BatchResult{{Id: 1}, {Id: 2}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"results":[{"status":200,"id":1},{"status":200,"id":2}]}`,
+		},
+		{
+			name:      "Atomic Failure",
+			inputBody: `{"operations": [{"op": "complete", "id": 1}, {"op": "complete", "id": 2}]}`,
+			mockBehavior: func(r *servicemocks.MockBatch) {
+				r.EXPECT().Run(gomock.Any(), 1, gomock.Any()).Return([]todoListSber.BatchResult{
+					{Id: 1, Err: todoListSber.ErrBatchRolledBack(1)},
+					{Id: 2, Err: todoListSber.ErrTodoItemNotFound(2)},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"results":[{"status":424,"id":1,"error":"batch rolled back because operation 1 failed"},{"status":404,"id":2,"error":"todo item with id 2 not found"}]}`,
+		},
+		{
+			name:      "Partial Failure",
+			inputBody: `{"mode": "partial", "operations": [{"op": "create", "item": {"title": "New", "date": "2024-06-05T20:00:00Z"}}, {"op": "update", "id": 2, "patch": {"title": ""}}]}`,
+			mockBehavior: func(r *servicemocks.MockBatch) {
+				r.EXPECT().Run(gomock.Any(), 1, gomock.Any()).Return([]todoListSber.BatchResult{
+					{Id: 3},
+					{Id: 2, Err: &todoListSber.ValidationError{Message: "title must not be empty"}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"results":[{"status":200,"id":3},{"status":400,"id":2,"error":"title must not be empty"}]}`,
+		},
+		{
+			name:                 "Invalid Input Body",
+			inputBody:            `{"operations": {}}`,
+			mockBehavior:         func(r *servicemocks.MockBatch) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Validation Error",
+			inputBody: `{"operations": []}`,
+			mockBehavior: func(r *servicemocks.MockBatch) {
+				r.EXPECT().Run(gomock.Any(), 1, gomock.Any()).Return(nil, &todoListSber.ValidationError{Message: "batch has no operations"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"batch has no operations"}`,
+		},
+		{
+			name:      "Service Error",
+			inputBody: `{"operations": [{"op": "delete", "id": 2}]}`,
+			mockBehavior: func(r *servicemocks.MockBatch) {
+				r.EXPECT().Run(gomock.Any(), 1, gomock.Any()).Return(nil, errors.New("Service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBatch := servicemocks.NewMockBatch(ctrl)
+			test.mockBehavior(mockBatch)
+
+			handler := Handler{services: &service.Service{Batch: mockBatch}}
+
+			router := gin.New()
+			router.POST("/api/todo/batch", withUser, handler.runBatch)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/todo/batch", bytes.NewBufferString(test.inputBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getBlockersResponse struct {
+	Data []todoListSber.TodoItem `json:"data"`
+}
+
+// @Tags dependencies
+// @Security ApiKeyAuth
+// @Summary getBlockers
+// @Description get the items blocking todo item ordered by date
+// @ID get-blockers
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getBlockersResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/blockers [get]
+func (h *Handler) getBlockers(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	blockers, err := h.services.Dependency.GetBlockers(c.Request.Context(), userId, itemId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getBlockersResponse{Data: blockers})
+}
+
+// @Tags dependencies
+// @Security ApiKeyAuth
+// @Summary addBlocker
+// @Description make another item block todo item, so that the item cannot be marked done while the blocker is open
+// @ID add-blocker
+// @Param id path string true "todo item id"
+// @Param blockerId path string true "blocking todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/blockers/{blockerId} [post]
+func (h *Handler) addBlocker(c *gin.Context) {
+	h.changeBlocker(c, h.services.Dependency.Add)
+}
+
+// @Tags dependencies
+// @Security ApiKeyAuth
+// @Summary removeBlocker
+// @Description stop another item from blocking todo item
+// @ID remove-blocker
+// @Param id path string true "todo item id"
+// @Param blockerId path string true "blocking todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/blockers/{blockerId} [delete]
+func (h *Handler) removeBlocker(c *gin.Context) {
+	h.changeBlocker(c, h.services.Dependency.Remove)
+}
+
+func (h *Handler) changeBlocker(c *gin.Context, change func(ctx context.Context, userId, itemId, blockerId int) error) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	blockerId, err := strconv.Atoi(c.Param("blockerId"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid blocker ID")
+		return
+	}
+	err = change(c.Request.Context(), userId, itemId, blockerId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestAddBlockerHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockDependency)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/todo/1/blockers/2",
+			mockBehavior: func(r *servicemocks.MockDependency) {
+				r.EXPECT().Add(gomock.Any(), 1, 1, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid Blocker ID",
+			url:                  "/api/todo/1/blockers/two",
+			mockBehavior:         func(r *servicemocks.MockDependency) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid blocker ID"}`,
+		},
+		{
+			name: "Cycle",
+			url:  "/api/todo/1/blockers/2",
+			mockBehavior: func(r *servicemocks.MockDependency) {
+				r.EXPECT().Add(gomock.Any(), 1, 1, 2).Return(todoListSber.ErrDependencyCycle)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"item cannot be blocked by itself or by an item it blocks"}`,
+		},
+		{
+			name: "Not Found",
+			url:  "/api/todo/1/blockers/3",
+			mockBehavior: func(r *servicemocks.MockDependency) {
+				r.EXPECT().Add(gomock.Any(), 1, 1, 3).Return(todoListSber.ErrTodoItemNotFound(3))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 3 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDependency := servicemocks.NewMockDependency(ctrl)
+			test.mockBehavior(mockDependency)
+
+			handler := Handler{services: &service.Service{Dependency: mockDependency}}
+
+			router := gin.New()
+			router.POST("/api/todo/:id/blockers/:blockerId", withUser, handler.addBlocker)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestBlockerHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+	mockDependency := servicemocks.NewMockDependency(ctrl)
+	mockDependency.EXPECT().GetBlockers(gomock.Any(), 1, 1).Return([]todoListSber.TodoItem{
+		{Id: 2, Title: "Build", Date: date, Priority: 4},
+	}, nil)
+	mockDependency.EXPECT().Remove(gomock.Any(), 1, 1, 2).Return(nil)
+
+	handler := Handler{services: &service.Service{Dependency: mockDependency}}
+
+	router := gin.New()
+	router.GET("/api/todo/:id/blockers", withUser, handler.getBlockers)
+	router.DELETE("/api/todo/:id/blockers/:blockerId", withUser, handler.removeBlocker)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/1/blockers", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":[{"id":2,"title":"Build","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":4}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/todo/1/blockers/2", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"status":"ok"}`, w.Body.String())
+}
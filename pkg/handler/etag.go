@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	todoListSber "todo-list-sber"
+)
+
+// itemETag renders a todo item as a strong entity tag: its version, which If-Match is checked
+// against, followed by a hash of the item as it is sent. The hash changes with what the version
+// does not follow, such as the subtask counts, so If-None-Match never holds back a changed item.
+func itemETag(item todoListSber.TodoItem) (string, error) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"%d-%x"`, item.Version, sum[:8]), nil
+}
+
+// noneMatch reports whether the If-None-Match header lists etag or is "*". Weak tags match too, as
+// If-None-Match uses the weak comparison.
+func noneMatch(header, etag string) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the item version named by the If-Match header, or nil when the header is
+// missing or "*". Only the version part of the tag is compared, so a change the version does not
+// follow does not fail the update. ok is false when the header cannot match any version: If-Match
+// uses the strong comparison, so weak tags never match, and only a single tag is accepted.
+func ifMatchVersion(header string) (version *int, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, true
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, false
+	}
+	tag := header[1 : len(header)-1]
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		tag = tag[:i]
+	}
+	v, err := strconv.Atoi(tag)
+	if err != nil {
+		return nil, false
+	}
+	return &v, true
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// eventsBatch is how many buffered events a stream reads at a time.
+const eventsBatch = 100
+
+// @Tags todo
+// @Security ApiKeyAuth
+// @Summary streamTodoEvents
+// @Description stream item events (item.created, item.updated, item.completed, item.deleted, item.restored) as Server-Sent Events.
+// @Description The id of every event can be sent back in the Last-Event-ID header, or the last_event_id query parameter, to resume after it.
+// @Description A reset event tells that events were missed because they have left the buffer; the client should reload its items.
+// @ID stream-todo-events
+// @Param Last-Event-ID header int false "id of the last event received"
+// @Param last_event_id query int false "id of the last event received, for clients that cannot set headers"
+// @Produce  text/event-stream
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/events [get]
+func (h *Handler) streamTodoEvents(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	lastIdStr := c.GetHeader("Last-Event-ID")
+	if lastIdStr == "" {
+		lastIdStr = c.Query("last_event_id")
+	}
+
+	var lastId int
+	if lastIdStr != "" {
+		lastId, err = strconv.Atoi(lastIdStr)
+		if err != nil || lastId < 0 {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid Last-Event-ID")
+			return
+		}
+	}
+
+	// Subscribing before reading the buffer makes sure no event published meanwhile is missed.
+	wake, unsubscribe := h.services.Event.Subscribe(userId)
+	defer unsubscribe()
+	if lastIdStr == "" {
+		ctx, cancel := h.streamQueryContext(c)
+		lastId, err = h.services.Event.Latest(ctx, userId)
+		cancel()
+		if err != nil {
+			newServiceErrorResponse(c, err)
+			return
+		}
+	}
+
+	// The server write timeout is meant for ordinary requests; a stream stays open.
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		slog.Debug("error lifting write deadline of event stream", "error", err)
+	}
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	poll := time.NewTicker(h.eventsPollInterval)
+	defer poll.Stop()
+	for {
+		lastId, err = h.sendEvents(c, userId, lastId)
+		if err != nil {
+			slog.Error("error streaming events", "user_id", userId, "error", err)
+			return
+		}
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.streamsDone:
+			return
+		case <-wake:
+		case <-poll.C:
+			// A comment keeps proxies from closing an idle stream.
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// sendEvents writes the buffered events after lastId and returns the id of the last one written.
+func (h *Handler) sendEvents(c *gin.Context, userId, lastId int) (int, error) {
+	for {
+		ctx, cancel := h.streamQueryContext(c)
+		events, lost, err := h.services.Event.Since(ctx, userId, lastId, eventsBatch)
+		if err == nil && lost {
+			lastId, err = h.services.Event.Latest(ctx, userId)
+		}
+		cancel()
+		if err != nil {
+			return lastId, err
+		}
+		if lost {
+			fmt.Fprintf(c.Writer, "id: %d\nevent: reset\ndata: {}\n\n", lastId)
+		}
+		for _, event := range events {
+			writeEvent(c, event)
+			lastId = event.Id
+		}
+		c.Writer.Flush()
+		if len(events) < eventsBatch {
+			return lastId, nil
+		}
+	}
+}
+
+func writeEvent(c *gin.Context, event todoListSber.StoredEvent) {
+	fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Event, event.Payload)
+}
+
+// streamQueryContext bounds one query of an event stream like queryDeadline bounds a request.
+func (h *Handler) streamQueryContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	if h.queryTimeout <= 0 {
+		return context.WithCancel(c.Request.Context())
+	}
+	return context.WithTimeout(c.Request.Context(), h.queryTimeout)
+}
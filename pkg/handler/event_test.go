@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestStreamTodoEventsHandler(t *testing.T) {
+	created := todoListSber.StoredEvent{Id: 5, Event: "item.created", Payload: []byte(`{"event":"item.created"}`)}
+	deleted := todoListSber.StoredEvent{Id: 9, Event: "item.deleted", Payload: []byte(`{"event":"item.deleted"}`)}
+	tests := []struct {
+		name                 string
+		url                  string
+		lastEventId          string
+		mockBehavior         func(r *servicemocks.MockEvent, cancel context.CancelFunc)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Resume",
+			url:         "/api/todo/events",
+			lastEventId: "4",
+			mockBehavior: func(r *servicemocks.MockEvent, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+				r.EXPECT().Since(gomock.Any(), 1, 4, eventsBatch).DoAndReturn(
+					func(ctx context.Context, userId, lastId, limit int) ([]todoListSber.StoredEvent, bool, error) {
+						cancel()
+						return []todoListSber.StoredEvent{created, deleted}, false, nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: "id: 5\nevent: item.created\ndata: {\"event\":\"item.created\"}\n\n" +
+				"id: 9\nevent: item.deleted\ndata: {\"event\":\"item.deleted\"}\n\n",
+		},
+		{
+			name: "Lost Events",
+			url:  "/api/todo/events?last_event_id=2",
+			mockBehavior: func(r *servicemocks.MockEvent, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+				r.EXPECT().Since(gomock.Any(), 1, 2, eventsBatch).Return(nil, true, nil)
+				r.EXPECT().Latest(gomock.Any(), 1).DoAndReturn(func(ctx context.Context, userId int) (int, error) {
+					cancel()
+					return 9, nil
+				})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "id: 9\nevent: reset\ndata: {}\n\n",
+		},
+		{
+			name: "From Now",
+			url:  "/api/todo/events",
+			mockBehavior: func(r *servicemocks.MockEvent, cancel context.CancelFunc) {
+				r.EXPECT().Subscribe(1).Return(make(chan struct{}), func() {})
+				r.EXPECT().Latest(gomock.Any(), 1).Return(9, nil)
+				r.EXPECT().Since(gomock.Any(), 1, 9, eventsBatch).DoAndReturn(
+					func(ctx context.Context, userId, lastId, limit int) ([]todoListSber.StoredEvent, bool, error) {
+						cancel()
+						return nil, false, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "",
+		},
+		{
+			name:                 "Invalid Last Event ID",
+			url:                  "/api/todo/events",
+			lastEventId:          "latest",
+			mockBehavior:         func(r *servicemocks.MockEvent, cancel context.CancelFunc) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid Last-Event-ID"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			mockEvent := servicemocks.NewMockEvent(ctrl)
+			test.mockBehavior(mockEvent, cancel)
+
+			handler := Handler{services: &service.Service{Event: mockEvent}, eventsPollInterval: time.Minute}
+
+			router := gin.New()
+			router.GET("/api/todo/events", withUser, handler.streamTodoEvents)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil).WithContext(ctx)
+			if test.lastEventId != "" {
+				req.Header.Set("Last-Event-ID", test.lastEventId)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+			if test.expectedStatusCode == http.StatusOK {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
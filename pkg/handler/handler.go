@@ -4,34 +4,106 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"sync"
+	"time"
 	_ "todo-list-sber/docs"
 	"todo-list-sber/pkg/service"
 )
 
 type Handler struct {
-	services *service.Service
+	services     *service.Service
+	queryTimeout time.Duration
+	// eventsPollInterval is how often event streams look for events of other server instances.
+	eventsPollInterval time.Duration
+	// streamsDone is closed by CloseStreams to end the event streams on shutdown.
+	streamsDone      chan struct{}
+	closeStreamsOnce sync.Once
 }
 
-func NewHandler(services *service.Service) *Handler {
+// NewHandler creates the HTTP handlers. A positive queryTimeout limits how long the
+// services may work on behalf of a single API request.
+func NewHandler(services *service.Service, queryTimeout, eventsPollInterval time.Duration) *Handler {
 	return &Handler{
-		services: services,
+		services:           services,
+		queryTimeout:       queryTimeout,
+		eventsPollInterval: eventsPollInterval,
+		streamsDone:        make(chan struct{}),
 	}
 }
 
+// CloseStreams ends the open event streams, which would otherwise hold up a graceful shutdown.
+func (h *Handler) CloseStreams() {
+	h.closeStreamsOnce.Do(func() {
+		close(h.streamsDone)
+	})
+}
+
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	api := router.Group("/api")
+	auth := router.Group("/auth", h.queryDeadline)
+	{
+		auth.POST("/sign-up", h.signUp)
+		auth.POST("/sign-in", h.signIn)
+	}
+	// The event stream outlives the query deadline of a request; it bounds each of its queries instead.
+	router.GET("/api/todo/events", h.userIdentity, h.streamTodoEvents)
+	api := router.Group("/api", h.queryDeadline, h.userIdentity)
 	{
 		todo := api.Group("/todo")
 		{
 			todo.POST("/", h.createTodoItem)
 			todo.GET("/", h.getAllTodoItems)
+			todo.POST("/batch", h.runBatch)
 			todo.GET("/:id", h.getTodoItemById)
 			todo.DELETE("/:id", h.deleteTodoItem)
 			todo.PUT("/:id", h.updateTodoItem)
+			todo.PATCH("/:id", h.patchTodoItem)
 			todo.GET("/done", h.GetDoneTodoItems)
 			todo.GET("/undone", h.GetUndoneTodoItems)
+			todo.GET("/search", h.searchTodoItems)
+			todo.GET("/trash", h.getTrash)
+			todo.GET("/:id/occurrences", h.getTodoItemOccurrences)
+			todo.GET("/:id/children", h.getTodoItemChildren)
+			todo.GET("/:id/tree", h.getTodoItemTree)
+			todo.PUT("/:id/parent", h.moveTodoItem)
+			todo.POST("/:id/restore", h.restoreTodoItem)
+			todo.GET("/:id/history", h.getTodoItemHistory)
+			todo.POST("/:id/revert/:rev", h.revertTodoItem)
+			todo.GET("/:id/blockers", h.getBlockers)
+			todo.POST("/:id/blockers/:blockerId", h.addBlocker)
+			todo.DELETE("/:id/blockers/:blockerId", h.removeBlocker)
+			todo.POST("/:id/tags/:tagId", h.attachTag)
+			todo.DELETE("/:id/tags/:tagId", h.detachTag)
+			todo.POST("/:id/reminders", h.createReminder)
+			todo.GET("/:id/reminders", h.getReminders)
+			todo.DELETE("/:id/reminders/:reminderId", h.deleteReminder)
+		}
+		lists := api.Group("/lists")
+		{
+			lists.POST("/", h.createTodoList)
+			lists.GET("/", h.getAllTodoLists)
+			lists.GET("/:id", h.getTodoListById)
+			lists.PUT("/:id", h.updateTodoList)
+			lists.DELETE("/:id", h.deleteTodoList)
+			lists.GET("/:id/items", h.getTodoListItems)
+			lists.POST("/:id/items", h.createTodoListItem)
+		}
+		tags := api.Group("/tags")
+		{
+			tags.POST("/", h.createTag)
+			tags.GET("/", h.getAllTags)
+			tags.GET("/:id", h.getTagById)
+			tags.PUT("/:id", h.updateTag)
+			tags.DELETE("/:id", h.deleteTag)
+		}
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.POST("/", h.createWebhook)
+			webhooks.GET("/", h.getAllWebhooks)
+			webhooks.GET("/:id", h.getWebhookById)
+			webhooks.DELETE("/:id", h.deleteWebhook)
+			webhooks.GET("/:id/deliveries", h.getWebhookDeliveries)
 		}
 	}
 	return router
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getHistoryResponse struct {
+	Data []todoListSber.Revision `json:"data"`
+}
+
+// @Tags history
+// @Security ApiKeyAuth
+// @Summary getTodoItemHistory
+// @Description get the revisions of todo item, latest first. Every update records the fields it changed with their old and new values, the user who made it and the time
+// @ID get-todo-item-history
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getHistoryResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/history [get]
+func (h *Handler) getTodoItemHistory(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	revisions, err := h.services.TodoItem.GetHistory(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getHistoryResponse{Data: revisions})
+}
+
+// @Tags history
+// @Security ApiKeyAuth
+// @Summary revertTodoItem
+// @Description bring todo item back to its state right after revision rev, or as it was created for rev 0. The revert is recorded as a new revision
+// @ID revert-todo-item
+// @Param id path string true "todo item id"
+// @Param rev path int true "revision"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409,412 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/revert/{rev} [post]
+func (h *Handler) revertTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid revision")
+		return
+	}
+	if err := h.services.TodoItem.Revert(c.Request.Context(), userId, id, revision); err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestGetTodoItemHistoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+	mockTodoItem.EXPECT().GetHistory(gomock.Any(), 1, 1).Return([]todoListSber.Revision{{
+		Revision:  1,
+		ItemId:    1,
+		ActorId:   1,
+		Changes:   []todoListSber.FieldChange{{Field: "is_done", Old: json.RawMessage(`false`), New: json.RawMessage(`true`)}},
+		CreatedAt: time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC),
+	}}, nil)
+	mockTodoItem.EXPECT().GetHistory(gomock.Any(), 1, 2).Return(nil, todoListSber.ErrTodoItemNotFound(2))
+
+	handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+	router := gin.New()
+	router.GET("/api/todo/:id/history", withUser, handler.getTodoItemHistory)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/1/history", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":[{"revision":1,"item_id":1,"actor_id":1,"changes":[{"field":"is_done","old":false,"new":true}],"created_at":"2024-06-05T20:00:00Z"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/2/history", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":"todo item with id 2 not found"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/one/history", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"error":"Invalid ID"}`, w.Body.String())
+}
+
+func TestRevertTodoItemHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/todo/1/revert/2",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Revert(gomock.Any(), 1, 1, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid ID",
+			url:                  "/api/todo/one/revert/2",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid ID"}`,
+		},
+		{
+			name:                 "Invalid Revision",
+			url:                  "/api/todo/1/revert/latest",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid revision"}`,
+		},
+		{
+			name: "Revision Not Found",
+			url:  "/api/todo/1/revert/9",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Revert(gomock.Any(), 1, 1, 9).Return(todoListSber.ErrRevisionNotFound(1, 9))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"revision 9 of todo item 1 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+			router := gin.New()
+			router.POST("/api/todo/:id/revert/:rev", withUser, handler.revertTodoItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestQueryDeadline(t *testing.T) {
+	tests := []struct {
+		name         string
+		queryTimeout time.Duration
+		hasDeadline  bool
+	}{
+		{name: "With Timeout", queryTimeout: time.Second, hasDeadline: true},
+		{name: "Disabled", queryTimeout: 0, hasDeadline: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := Handler{queryTimeout: test.queryTimeout}
+
+			var hasDeadline bool
+			r := gin.New()
+			r.GET("/api/todo", handler.queryDeadline, func(c *gin.Context) {
+				_, hasDeadline = c.Request.Context().Deadline()
+				c.Status(http.StatusOK)
+			})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/api/todo", nil)
+
+			r.ServeHTTP(w, req)
+
+			if hasDeadline != test.hasDeadline {
+				t.Errorf("expected deadline %t; got %t", test.hasDeadline, hasDeadline)
+			}
+		})
+	}
+}
+
+func TestUserIdentity(t *testing.T) {
+	tests := []struct {
+		name                 string
+		headerName           string
+		headerValue          string
+		mockBehavior         func(r *servicemocks.MockAuthorization, token string)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:        "Ok",
+			headerName:  "Authorization",
+			headerValue: "Bearer token",
+			mockBehavior: func(r *servicemocks.MockAuthorization, token string) {
+				r.EXPECT().ParseToken(token).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: "1",
+		},
+		{
+			name:                 "No Header",
+			headerName:           "",
+			mockBehavior:         func(r *servicemocks.MockAuthorization, token string) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"empty auth header"}`,
+		},
+		{
+			name:                 "Invalid Bearer",
+			headerName:           "Authorization",
+			headerValue:          "Bearr token",
+			mockBehavior:         func(r *servicemocks.MockAuthorization, token string) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid auth header"}`,
+		},
+		{
+			name:                 "Empty Token",
+			headerName:           "Authorization",
+			headerValue:          "Bearer ",
+			mockBehavior:         func(r *servicemocks.MockAuthorization, token string) {},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid auth header"}`,
+		},
+		{
+			name:        "Parse Error",
+			headerName:  "Authorization",
+			headerValue: "Bearer token",
+			mockBehavior: func(r *servicemocks.MockAuthorization, token string) {
+				r.EXPECT().ParseToken(token).Return(0, &todoListSber.UnauthorizedError{Message: "invalid access token"})
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid access token"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuth := servicemocks.NewMockAuthorization(ctrl)
+			test.mockBehavior(mockAuth, "token")
+
+			services := &service.Service{Authorization: mockAuth}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.GET("/protected", handler.userIdentity, func(c *gin.Context) {
+				id, _ := c.Get(userCtx)
+				c.String(http.StatusOK, fmt.Sprintf("%d", id.(int)))
+			})
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/protected", nil)
+			if test.headerName != "" {
+				req.Header.Set(test.headerName, test.headerValue)
+			}
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getRemindersResponse struct {
+	Data []todoListSber.Reminder `json:"data"`
+}
+
+// @Tags reminders
+// @Security ApiKeyAuth
+// @Summary createReminder
+// @Description create reminder for todo item, either at an absolute time "at" or "before_minutes" before the item date
+// @ID create-reminder
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.Reminder true "reminder info"
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/reminders [post]
+func (h *Handler) createReminder(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var input todoListSber.Reminder
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	id, err := h.services.Reminder.Create(c.Request.Context(), userId, itemId, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Tags reminders
+// @Security ApiKeyAuth
+// @Summary getReminders
+// @Description get reminders of todo item ordered by the time they fire
+// @ID get-reminders
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getRemindersResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/reminders [get]
+func (h *Handler) getReminders(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	reminders, err := h.services.Reminder.GetByItem(c.Request.Context(), userId, itemId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getRemindersResponse{Data: reminders})
+}
+
+// @Tags reminders
+// @Security ApiKeyAuth
+// @Summary deleteReminder
+// @Description delete reminder of todo item
+// @ID delete-reminder
+// @Param id path string true "todo item id"
+// @Param reminderId path string true "reminder id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/reminders/{reminderId} [delete]
+func (h *Handler) deleteReminder(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	id, err := strconv.Atoi(c.Param("reminderId"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid reminder ID")
+		return
+	}
+	err = h.services.Reminder.Delete(c.Request.Context(), userId, itemId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
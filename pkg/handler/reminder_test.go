@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestCreateReminderHandler(t *testing.T) {
+	before := 15
+	tests := []struct {
+		name                 string
+		url                  string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockReminder)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			url:       "/api/todo/1/reminders",
+			inputBody: `{"before_minutes": 15}`,
+			mockBehavior: func(r *servicemocks.MockReminder) {
+				r.EXPECT().Create(gomock.Any(), 1, 1, todoListSber.Reminder{BeforeMinutes: &before}).Return(3, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":3}`,
+		},
+		{
+			name:                 "Invalid ID",
+			url:                  "/api/todo/one/reminders",
+			inputBody:            `{"before_minutes": 15}`,
+			mockBehavior:         func(r *servicemocks.MockReminder) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid ID"}`,
+		},
+		{
+			name:                 "Invalid Body",
+			url:                  "/api/todo/1/reminders",
+			inputBody:            `{"at": "tomorrow"}`,
+			mockBehavior:         func(r *servicemocks.MockReminder) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Validation Error",
+			url:       "/api/todo/1/reminders",
+			inputBody: `{}`,
+			mockBehavior: func(r *servicemocks.MockReminder) {
+				r.EXPECT().Create(gomock.Any(), 1, 1, todoListSber.Reminder{}).
+					Return(0, &todoListSber.ValidationError{Message: "reminder must have exactly one of at and before_minutes"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"reminder must have exactly one of at and before_minutes"}`,
+		},
+		{
+			name:      "Item Not Found",
+			url:       "/api/todo/2/reminders",
+			inputBody: `{"before_minutes": 15}`,
+			mockBehavior: func(r *servicemocks.MockReminder) {
+				r.EXPECT().Create(gomock.Any(), 1, 2, todoListSber.Reminder{BeforeMinutes: &before}).Return(0, todoListSber.ErrTodoItemNotFound(2))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReminder := servicemocks.NewMockReminder(ctrl)
+			test.mockBehavior(mockReminder)
+
+			handler := Handler{services: &service.Service{Reminder: mockReminder}}
+
+			router := gin.New()
+			router.POST("/api/todo/:id/reminders", withUser, handler.createReminder)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, bytes.NewBufferString(test.inputBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestReminderHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	before := 15
+	sentAt := time.Date(2024, time.June, 5, 19, 45, 0, 0, time.UTC)
+	mockReminder := servicemocks.NewMockReminder(ctrl)
+	mockReminder.EXPECT().GetByItem(gomock.Any(), 1, 1).Return([]todoListSber.Reminder{
+		{Id: 3, ItemId: 1, BeforeMinutes: &before, RemindAt: sentAt, SentAt: &sentAt},
+	}, nil)
+	mockReminder.EXPECT().Delete(gomock.Any(), 1, 1, 4).Return(todoListSber.ErrReminderNotFound(4))
+
+	handler := Handler{services: &service.Service{Reminder: mockReminder}}
+
+	router := gin.New()
+	router.GET("/api/todo/:id/reminders", withUser, handler.getReminders)
+	router.DELETE("/api/todo/:id/reminders/:reminderId", withUser, handler.deleteReminder)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/1/reminders", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":[{"id":3,"item_id":1,"before_minutes":15,"remind_at":"2024-06-05T19:45:00Z","sent_at":"2024-06-05T19:45:00Z"}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/todo/1/reminders/4", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":"reminder with id 4 not found"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/todo/1/reminders/last", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"error":"Invalid reminder ID"}`, w.Body.String())
+}
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
-	"log"
+	"log/slog"
+	"net/http"
+	todoListSber "todo-list-sber"
 )
 
 type errorResponse struct {
@@ -14,6 +18,61 @@ type statusResponse struct {
 }
 
 func newErrorResponse(c *gin.Context, statusCode int, message string) {
-	log.Printf(message)
+	logError(c, statusCode, message)
 	c.AbortWithStatusJSON(statusCode, errorResponse{message})
 }
+
+// newServiceErrorResponse is the single place where errors returned by the services are
+// translated into HTTP status codes.
+func newServiceErrorResponse(c *gin.Context, err error) {
+	statusCode := errorStatusCode(err)
+	logError(c, statusCode, err.Error())
+	c.AbortWithStatusJSON(statusCode, errorResponse{errorMessage(statusCode, err)})
+}
+
+// logError logs the error a request is answered with, server errors at the error level. It gets
+// the full text of the error, which the client may not be shown.
+func logError(c *gin.Context, statusCode int, message string) {
+	level := slog.LevelInfo
+	if statusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	slog.Log(c.Request.Context(), level, "request failed", "status", statusCode, "error", message)
+}
+
+// errorMessage is the message a client is answered with for err. The text of server errors may
+// carry SQL or driver details, so it is only logged and the client gets the status text instead.
+func errorMessage(statusCode int, err error) string {
+	if statusCode < http.StatusInternalServerError {
+		return err.Error()
+	}
+	return http.StatusText(statusCode)
+}
+
+func errorStatusCode(err error) int {
+	var notFound *todoListSber.NotFoundError
+	var validation *todoListSber.ValidationError
+	var conflict *todoListSber.ConflictError
+	var unauthorized *todoListSber.UnauthorizedError
+	var preconditionFailed *todoListSber.PreconditionFailedError
+	var failedDependency *todoListSber.FailedDependencyError
+
+	switch {
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &validation):
+		return http.StatusBadRequest
+	case errors.As(err, &conflict):
+		return http.StatusConflict
+	case errors.As(err, &unauthorized):
+		return http.StatusUnauthorized
+	case errors.As(err, &preconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.As(err, &failedDependency):
+		return http.StatusFailedDependency
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
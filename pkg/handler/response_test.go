@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	todoListSber "todo-list-sber"
+)
+
+func TestErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{name: "Not Found", err: todoListSber.ErrTodoItemNotFound(1), expectedStatusCode: http.StatusNotFound},
+		{name: "Wrapped Not Found", err: fmt.Errorf("get: %w", todoListSber.ErrTodoItemNotFound(1)), expectedStatusCode: http.StatusNotFound},
+		{name: "Validation", err: &todoListSber.ValidationError{Message: "bad"}, expectedStatusCode: http.StatusBadRequest},
+		{name: "Conflict", err: &todoListSber.ConflictError{Message: "taken"}, expectedStatusCode: http.StatusConflict},
+		{name: "Deadline", err: context.DeadlineExceeded, expectedStatusCode: http.StatusGatewayTimeout},
+		{name: "Other", err: errors.New("connection refused"), expectedStatusCode: http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if code := errorStatusCode(test.err); code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, code)
+			}
+		})
+	}
+}
+
+func TestErrorMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedMessage string
+	}{
+		{name: "Client Error", err: todoListSber.ErrTodoItemNotFound(1), expectedMessage: "todo item with id 1 not found"},
+		{name: "Deadline", err: fmt.Errorf("select: %w", context.DeadlineExceeded), expectedMessage: "Gateway Timeout"},
+		{name: "Other", err: errors.New(`pq: relation "todo_items" does not exist`), expectedMessage: "Internal Server Error"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if message := errorMessage(errorStatusCode(test.err), test.err); message != test.expectedMessage {
+				t.Errorf("expected message %q; got %q", test.expectedMessage, message)
+			}
+		})
+	}
+}
+
+func TestServiceErrorResponseLog(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/todo", nil)
+	newServiceErrorResponse(c, errors.New(`pq: relation "todo_items" does not exist %d`))
+
+	if w.Code != http.StatusInternalServerError || w.Body.String() != `{"error":"Internal Server Error"}` {
+		t.Errorf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected the error to be logged once; got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], "level=ERROR") || !strings.Contains(lines[0], `does not exist %d`) {
+		t.Errorf("expected an error record with the full text; got %q", lines[0])
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getChildrenResponse struct {
+	Data []todoListSber.TodoItem `json:"data"`
+}
+
+type getTreeResponse struct {
+	Data todoListSber.TodoItemNode `json:"data"`
+}
+
+// @Tags subtasks
+// @Security ApiKeyAuth
+// @Summary getTodoItemChildren
+// @Description get the direct subtasks of todo item ordered by date, each with the completed/total counts of its own subtasks
+// @ID get-todo-item-children
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getChildrenResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/children [get]
+func (h *Handler) getTodoItemChildren(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	children, err := h.services.TodoItem.GetChildren(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getChildrenResponse{Data: children})
+}
+
+// @Tags subtasks
+// @Security ApiKeyAuth
+// @Summary getTodoItemTree
+// @Description get todo item with its subtasks at any depth
+// @ID get-todo-item-tree
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getTreeResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/tree [get]
+func (h *Handler) getTodoItemTree(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	tree, err := h.services.TodoItem.GetTree(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getTreeResponse{Data: tree})
+}
+
+// @Tags subtasks
+// @Security ApiKeyAuth
+// @Summary moveTodoItem
+// @Description move todo item with its subtasks under another item, or to the top level with "parent_id": null
+// @ID move-todo-item
+// @Param id path string true "todo item id"
+// @Param input body todoListSber.MoveItemInput true "new parent"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/parent [put]
+func (h *Handler) moveTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var input todoListSber.MoveItemInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	if err := h.services.TodoItem.Move(c.Request.Context(), userId, id, input); err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
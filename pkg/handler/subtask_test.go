@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestMoveTodoItemHandler(t *testing.T) {
+	parentId := 2
+	tests := []struct {
+		name                 string
+		url                  string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			url:       "/api/todo/1/parent",
+			inputBody: `{"parent_id": 2}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Move(gomock.Any(), 1, 1, todoListSber.MoveItemInput{ParentId: &parentId}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "Top Level",
+			url:       "/api/todo/1/parent",
+			inputBody: `{"parent_id": null}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Move(gomock.Any(), 1, 1, todoListSber.MoveItemInput{}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid ID",
+			url:                  "/api/todo/one/parent",
+			inputBody:            `{"parent_id": 2}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid ID"}`,
+		},
+		{
+			name:                 "Invalid Body",
+			url:                  "/api/todo/1/parent",
+			inputBody:            `{"parent_id": "two"}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Cycle",
+			url:       "/api/todo/1/parent",
+			inputBody: `{"parent_id": 2}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Move(gomock.Any(), 1, 1, todoListSber.MoveItemInput{ParentId: &parentId}).Return(todoListSber.ErrItemCycle)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"item cannot be moved under itself or one of its subtasks"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+			router := gin.New()
+			router.PUT("/api/todo/:id/parent", withUser, handler.moveTodoItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", test.url, bytes.NewBufferString(test.inputBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestSubtaskHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parentId := 1
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+	child := todoListSber.TodoItem{Id: 2, Title: "Child", Date: date, Priority: 4, ParentId: &parentId,
+		Subtasks: &todoListSber.SubtaskCounts{Completed: 1, Total: 1}}
+	grandchild := todoListSber.TodoItem{Id: 3, Title: "Grandchild", Date: date, IsDone: true, Priority: 4, ParentId: &child.Id}
+	mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+	mockTodoItem.EXPECT().GetChildren(gomock.Any(), 1, 1).Return([]todoListSber.TodoItem{child}, nil)
+	mockTodoItem.EXPECT().GetTree(gomock.Any(), 1, 2).Return(todoListSber.TodoItemNode{
+		TodoItem: child,
+		Children: []todoListSber.TodoItemNode{{TodoItem: grandchild, Children: []todoListSber.TodoItemNode{}}},
+	}, nil)
+	mockTodoItem.EXPECT().GetTree(gomock.Any(), 1, 4).Return(todoListSber.TodoItemNode{}, todoListSber.ErrTodoItemNotFound(4))
+
+	handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+	router := gin.New()
+	router.GET("/api/todo/:id/children", withUser, handler.getTodoItemChildren)
+	router.GET("/api/todo/:id/tree", withUser, handler.getTodoItemTree)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/1/children", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":[{"id":2,"title":"Child","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":4,"parent_id":1,"subtasks":{"completed":1,"total":1}}]}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/2/tree", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":{"id":2,"title":"Child","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":4,"parent_id":1,"subtasks":{"completed":1,"total":1},`+
+		`"children":[{"id":3,"title":"Grandchild","description":"","date":"2024-06-05T20:00:00Z","is_done":true,"priority":4,"parent_id":2,"children":[]}]}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/todo/4/tree", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":"todo item with id 4 not found"}`, w.Body.String())
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+// parseTagFilter reads the repeatable "tag" query parameter and "tag_mode", which is
+// "any" (the default) or "all".
+func parseTagFilter(c *gin.Context) (todoListSber.TagFilter, error) {
+	filter := todoListSber.TagFilter{Tags: c.QueryArray("tag")}
+	switch c.Query("tag_mode") {
+	case "", "any":
+	case "all":
+		filter.MatchAll = true
+	default:
+		return filter, errors.New("Invalid tag_mode")
+	}
+	return filter, nil
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary createTag
+// @Description create tag
+// @ID create-tag
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.Tag true "tag info"
+// @Success 200 {integer} integer 1
+// @Failure 400,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags [post]
+func (h *Handler) createTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var input todoListSber.Tag
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	id, err := h.services.Tag.Create(c.Request.Context(), userId, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary getAllTags
+// @Description get all tags ordered by name
+// @ID get-all-tags
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} todoListSber.Tag
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags [get]
+func (h *Handler) getAllTags(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	tags, err := h.services.Tag.GetAll(c.Request.Context(), userId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tags})
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary getTagById
+// @Description get tag by id
+// @ID get-tag-by-id
+// @Param id path string true "tag id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} todoListSber.Tag
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [get]
+func (h *Handler) getTagById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	tag, err := h.services.Tag.GetById(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": tag})
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary updateTag
+// @Description rename tag
+// @ID update-tag
+// @Param id path string true "tag id"
+// @Param input body todoListSber.Tag true "tag info"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [put]
+func (h *Handler) updateTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var input todoListSber.Tag
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	err = h.services.Tag.Update(c.Request.Context(), userId, id, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary deleteTag
+// @Description delete tag and detach it from every item
+// @ID delete-tag
+// @Param id path string true "tag id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/tags/{id} [delete]
+func (h *Handler) deleteTag(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	err = h.services.Tag.Delete(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary attachTag
+// @Description attach tag to todo item
+// @ID attach-tag
+// @Param id path string true "todo item id"
+// @Param tagId path string true "tag id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/tags/{tagId} [post]
+func (h *Handler) attachTag(c *gin.Context) {
+	h.changeItemTag(c, h.services.Tag.Attach)
+}
+
+// @Tags tags
+// @Security ApiKeyAuth
+// @Summary detachTag
+// @Description detach tag from todo item
+// @ID detach-tag
+// @Param id path string true "todo item id"
+// @Param tagId path string true "tag id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/tags/{tagId} [delete]
+func (h *Handler) detachTag(c *gin.Context) {
+	h.changeItemTag(c, h.services.Tag.Detach)
+}
+
+func (h *Handler) changeItemTag(c *gin.Context, change func(ctx context.Context, userId, itemId, tagId int) error) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	itemId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	tagId, err := strconv.Atoi(c.Param("tagId"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+	err = change(c.Request.Context(), userId, itemId, tagId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestTagFilterHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Any",
+			url:  "/api/todo?tag=work&tag=urgent",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.TodoItemQuery{Tags: todoListSber.TagFilter{Tags: []string{"work", "urgent"}}, Limit: todoListSber.DefaultPageLimit}
+				r.EXPECT().GetAll(gomock.Any(), 1, query).Return(todoListSber.TodoItemPage{Items: []todoListSber.TodoItem{
+					{Id: 1, Title: "Report", Date: time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC), Tags: []string{"urgent", "work"}},
+				}, Total: 1}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Report","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0,"tags":["urgent","work"]}],"pagination":{"total":1,"limit":50,"offset":0}}`,
+		},
+		{
+			name: "All",
+			url:  "/api/todo?tag=work&tag=urgent&tag_mode=all",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.TodoItemQuery{Tags: todoListSber.TagFilter{Tags: []string{"work", "urgent"}, MatchAll: true}, Limit: todoListSber.DefaultPageLimit}
+				r.EXPECT().GetAll(gomock.Any(), 1, query).Return(todoListSber.TodoItemPage{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":null,"pagination":{"total":0,"limit":50,"offset":0}}`,
+		},
+		{
+			name:                 "Invalid Mode",
+			url:                  "/api/todo?tag=work&tag_mode=some",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid tag_mode"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+			router := gin.New()
+			router.GET("/api/todo", withUser, handler.getAllTodoItems)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestAttachTagHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTag)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/todo/1/tags/2",
+			mockBehavior: func(r *servicemocks.MockTag) {
+				r.EXPECT().Attach(gomock.Any(), 1, 1, 2).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid Tag ID",
+			url:                  "/api/todo/1/tags/work",
+			mockBehavior:         func(r *servicemocks.MockTag) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid tag ID"}`,
+		},
+		{
+			name: "Tag Not Found",
+			url:  "/api/todo/1/tags/3",
+			mockBehavior: func(r *servicemocks.MockTag) {
+				r.EXPECT().Attach(gomock.Any(), 1, 1, 3).Return(todoListSber.ErrTagNotFound(3))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"tag with id 3 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTag := servicemocks.NewMockTag(ctrl)
+			test.mockBehavior(mockTag)
+
+			handler := Handler{services: &service.Service{Tag: mockTag}}
+
+			router := gin.New()
+			router.POST("/api/todo/:id/tags/:tagId", withUser, handler.attachTag)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestCreateTagHandlerConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTag := servicemocks.NewMockTag(ctrl)
+	mockTag.EXPECT().Create(gomock.Any(), 1, todoListSber.Tag{Name: "work"}).Return(0, todoListSber.ErrTagExists)
+
+	handler := Handler{services: &service.Service{Tag: mockTag}}
+
+	router := gin.New()
+	router.POST("/api/tags", withUser, handler.createTag)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/tags", bytes.NewBufferString(`{"name": "work"}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, `{"error":"tag with this name already exists"}`, w.Body.String())
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,9 +13,34 @@ import (
 )
 
 type getAllTodoItemsResponse struct {
+	Data       []todoListSber.TodoItem `json:"data"`
+	Pagination pagination              `json:"pagination"`
+}
+
+type pagination struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type searchTodoItemsResponse struct {
+	Data []todoListSber.SearchResult `json:"data"`
+}
+
+type occurrencesResponse struct {
+	Data []time.Time `json:"data"`
+}
+
+// defaultOccurrenceDays is the length of the range expanded when to is not given.
+const defaultOccurrenceDays = 30
+
+type cursorTodoItemsResponse struct {
 	Data []todoListSber.TodoItem `json:"data"`
+	Next string                  `json:"next,omitempty"`
+	Prev string                  `json:"prev,omitempty"`
 }
 
+// @Security ApiKeyAuth
 // @Summary createTodoItem
 // @Description create todo item date example: 2024-06-07T12:00:00Z
 // @ID create-todo-item
@@ -24,101 +53,409 @@ type getAllTodoItemsResponse struct {
 // @Failure default {object} errorResponse
 // @Router /api/todo [post]
 func (h *Handler) createTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 	var input todoListSber.TodoItem
 
 	if err := c.BindJSON(&input); err != nil {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
 		return
 	}
-	id, err := h.services.TodoItem.Create(input)
+	id, err := h.services.TodoItem.Create(c.Request.Context(), userId, input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"id": id})
 }
 
+// @Security ApiKeyAuth
 // @Summary getAllTodoItems
-// @Description get all todos
+// @Description get a page of todos filtered by status, date range, text and tags
 // @ID get-all-todo-items
 // @Accept  json
 // @Produce  json
-// @Success 200 {array} todoListSber.TodoItem
-// @Failure 400,404 {object} errorResponse
+// @Param status query string false "done, undone, ready (undone and not blocked by open items) or all (default)" Enums(all, done, undone, ready)
+// @Param from query string false "First day in format YYYY-MM-DD"
+// @Param to query string false "Last day in format YYYY-MM-DD"
+// @Param text query string false "Case-insensitive substring of the title or description"
+// @Param tag query []string false "Only items with these tags" collectionFormat(multi)
+// @Param tag_mode query string false "any (default) or all of the tags" Enums(any, all)
+// @Param sort query string false "Sort field, priority by default" Enums(priority, date, title, id)
+// @Param dir query string false "Sort direction, asc by default" Enums(asc, desc)
+// @Param limit query int false "Limit of items to return, 50 by default and at most 100"
+// @Param offset query int false "Offset of items to return"
+// @Success 200 {object} getAllTodoItemsResponse
+// @Failure 400 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /api/todo [get]
 func (h *Handler) getAllTodoItems(c *gin.Context) {
-	todoItems, err := h.services.GetAll()
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	query, err := parseTodoItemQuery(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := h.services.TodoItem.GetAll(c.Request.Context(), userId, query)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getAllTodoItemsResponse{
+		Data:       page.Items,
+		Pagination: pagination{Total: page.Total, Limit: query.Limit, Offset: query.Offset},
+	})
+}
+
+// parseTodoItemQuery reads the query parameters of GET /api/todo.
+func parseTodoItemQuery(c *gin.Context) (todoListSber.TodoItemQuery, error) {
+	query := todoListSber.TodoItemQuery{
+		Text:  c.Query("text"),
+		Sort:  c.Query("sort"),
+		Limit: todoListSber.DefaultPageLimit,
+	}
+
+	switch c.Query("status") {
+	case "", "all":
+	case "done", "undone":
+		isDone := c.Query("status") == "done"
+		query.IsDone = &isDone
+	case "ready":
+		query.Ready = true
+	default:
+		return query, errors.New("Invalid status")
+	}
+
+	var err error
+	if query.From, err = parseDay(c, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseDay(c, "to"); err != nil {
+		return query, err
+	}
+
+	switch c.Query("dir") {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		return query, errors.New("Invalid dir")
+	}
+
+	if query.Limit, query.Offset, err = parsePage(c); err != nil {
+		return query, err
+	}
+
+	query.Tags, err = parseTagFilter(c)
+	return query, err
+}
+
+// parsePage reads the limit and offset query parameters, DefaultPageLimit and 0 when not given.
+func parsePage(c *gin.Context) (limit, offset int, err error) {
+	limit = todoListSber.DefaultPageLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > todoListSber.MaxPageLimit {
+			return 0, 0, errors.New("Invalid limit")
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("Invalid offset")
+		}
+	}
+	return limit, offset, nil
+}
+
+// parseDay reads an optional YYYY-MM-DD query parameter.
+func parseDay(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s date format", param)
+	}
+	return &day, nil
+}
+
+// @Security ApiKeyAuth
+// @Summary searchTodoItems
+// @Description full-text search over the title and description of todos, best matches first
+// @ID search-todo-items
+// @Accept  json
+// @Produce  json
+// @Param q query string true "Words, \"quoted phrases\", OR and -excluded words"
+// @Param lang query string false "Stemming language, both by default" Enums(russian, english)
+// @Param limit query int false "Limit of items to return, 50 by default and at most 100"
+// @Param offset query int false "Offset of items to return"
+// @Success 200 {object} searchTodoItemsResponse
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/search [get]
+func (h *Handler) searchTodoItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	query := todoListSber.SearchQuery{
+		Text:     c.Query("q"),
+		Language: c.Query("lang"),
+	}
+	if query.Limit, query.Offset, err = parsePage(c); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.services.TodoItem.Search(c.Request.Context(), userId, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"data": todoItems})
+	c.JSON(http.StatusOK, searchTodoItemsResponse{Data: results})
 }
 
+// @Security ApiKeyAuth
 // @Summary getTodoItemById
-// @Description get todo item by id
+// @Description get todo item by id. The ETag header carries the version of the item and a hash of the item as sent; with a matching If-None-Match the answer is 304 without a body
 // @ID get-todo-item-by-id
 // @Param id path string true "get todo by id"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} todoListSber.TodoItem
+// @Success 304 "Not Modified"
 // @Failure 400,404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /api/todo/{id} [get]
 func (h *Handler) getTodoItemById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
 		return
 	}
-	todoItem, err := h.services.GetById(id)
+	todoItem, err := h.services.TodoItem.GetById(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	etag, err := itemETag(todoItem)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.Header("ETag", etag)
+	if noneMatch(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"data": todoItem})
 }
 
+// @Security ApiKeyAuth
+// @Summary getTodoItemOccurrences
+// @Description expand the occurrences of a recurring todo over a range of days in its timezone
+// @ID get-todo-item-occurrences
+// @Param id path string true "todo id"
+// @Param from query string false "First day in format YYYY-MM-DD, today by default"
+// @Param to query string false "Last day in format YYYY-MM-DD, 30 days after from by default"
+// @Param limit query int false "Limit of occurrences to return, 50 by default and at most 100"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} occurrencesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/occurrences [get]
+func (h *Handler) getTodoItemOccurrences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	from, err := parseDay(c, "from")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if from == nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		from = &today
+	}
+	to, err := parseDay(c, "to")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if to == nil {
+		last := from.AddDate(0, 0, defaultOccurrenceDays)
+		to = &last
+	}
+	limit := todoListSber.DefaultPageLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > todoListSber.MaxPageLimit {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	occurrences, err := h.services.TodoItem.GetOccurrences(c.Request.Context(), userId, id, *from, *to, limit)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, occurrencesResponse{Data: occurrences})
+}
+
+// @Security ApiKeyAuth
 // @Summary updateTodoItem
-// @Description update todo item
+// @Description replace all fields of a todo item: fields left out get the values of a new item, so an item sent without list_id leaves its list. With cascade=true marking the item done marks its subtasks done too. With If-Match the update is only applied to the version named by the ETag and fails with 412 once the item has changed
 // @ID update-todo-item
 // @Param id path string true "get todo by id"
-// @Param input body todoListSber.UpdateItemInput true "todo info"
+// @Param cascade query bool false "Mark the subtasks done as well when is_done is set to true"
+// @Param If-Match header string false "ETag the update is based on"
+// @Param input body todoListSber.ItemFields true "todo info"
 // @Accept  json
 // @Produce  json
 // @Success 200 {string} status ok
-// @Failure 400,404 {object} errorResponse
+// @Failure 400,404,412 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /api/todo/{id} [put]
 func (h *Handler) updateTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
 		return
 	}
-	var input todoListSber.UpdateItemInput
-	if err := c.BindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	cascade, version, ok := updateConditions(c)
+	if !ok {
+		return
+	}
+	var fields todoListSber.ItemFields
+	if err := c.ShouldBindJSON(&fields); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	fields.Cascade = cascade
+	fields.Version = version
+	err = h.services.TodoItem.Replace(c.Request.Context(), userId, id, fields)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// patchFormats maps the media types accepted by PATCH /api/todo/:id to their patch formats.
+var patchFormats = map[string]string{
+	"application/merge-patch+json": todoListSber.MergePatch,
+	"application/json-patch+json":  todoListSber.JSONPatch,
+}
+
+// @Security ApiKeyAuth
+// @Summary patchTodoItem
+// @Description change some fields of a todo item with a JSON Merge Patch (RFC 7396, null clears a field) or a JSON Patch (RFC 6902), chosen by Content-Type. The patched item is validated as a whole before it is saved; a failed JSON Patch test fails with 409. cascade and If-Match work as for PUT
+// @ID patch-todo-item
+// @Param id path string true "get todo by id"
+// @Param cascade query bool false "Mark the subtasks done as well when is_done is set to true"
+// @Param If-Match header string false "ETag the patch is based on"
+// @Param input body object true "merge patch or JSON patch of todoListSber.ItemFields"
+// @Accept  application/merge-patch+json
+// @Accept  application/json-patch+json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409,412,415 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id} [patch]
+func (h *Handler) patchTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	format, ok := patchFormats[c.ContentType()]
+	if !ok {
+		c.Header("Accept-Patch", "application/merge-patch+json, application/json-patch+json")
+		newErrorResponse(c, http.StatusUnsupportedMediaType, "Content-Type must be application/merge-patch+json or application/json-patch+json")
 		return
 	}
-	if input.IsDone == nil && input.Title == nil && input.Description == nil && input.Date == nil {
-		newErrorResponse(c, http.StatusBadRequest, "EOF")
+	cascade, version, ok := updateConditions(c)
+	if !ok {
 		return
 	}
-	err = h.services.Update(id, input)
+	document, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(bytes.TrimSpace(document)) == 0 {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	patch := todoListSber.ItemPatch{Format: format, Document: document, Cascade: cascade, Version: version}
+	err = h.services.TodoItem.Patch(c.Request.Context(), userId, id, patch)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// updateConditions parses the cascade query and the If-Match header of a change to an item. It
+// responds with the error itself and returns ok false when either is invalid.
+func updateConditions(c *gin.Context) (cascade bool, version *int, ok bool) {
+	if cascadeStr := c.Query("cascade"); cascadeStr != "" {
+		var err error
+		cascade, err = strconv.ParseBool(cascadeStr)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid cascade")
+			return false, nil, false
+		}
+	}
+	version, ok = ifMatchVersion(c.GetHeader("If-Match"))
+	if !ok {
+		newErrorResponse(c, http.StatusPreconditionFailed, "If-Match does not match any version")
+		return false, nil, false
+	}
+	return cascade, version, true
+}
+
+// @Security ApiKeyAuth
 // @Summary deleteTodoItem
-// @Description delete todo item
+// @Description delete todo item with its subtasks by moving them to the trash, see /api/todo/trash
 // @ID delete-todo-item
 // @Param id path string true "get todo by id"
 // @Accept  json
@@ -129,14 +466,19 @@ func (h *Handler) updateTodoItem(c *gin.Context) {
 // @Failure default {object} errorResponse
 // @Router /api/todo/{id} [delete]
 func (h *Handler) deleteTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
 		return
 	}
-	err = h.services.Delete(id)
+	err = h.services.TodoItem.Delete(c.Request.Context(), userId, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
@@ -144,21 +486,38 @@ func (h *Handler) deleteTodoItem(c *gin.Context) {
 
 // GetDoneTodoItems
 // @Tags get by is_done
+// @Security ApiKeyAuth
 // @Summary getDoneTodoItems
 // @Description get done todos by date with pagination
 // @ID get-done-todo-items
 // @Accept  json
 // @Produce  json
+// @Description Without offset the todos are paged by an opaque cursor in (date, id) order and
+// @Description the response is an object with data and the next and prev cursors. With limit
+// @Description and offset the response is a plain array of todos.
 // @Param date query string false "Date in format YYYY-MM-DD"
-// @Param limit query int true "Limit of items to return"
-// @Param offset query int true "Offset of items to return"
-// @Success 200 {array} todoListSber.TodoItem
+// @Param limit query int false "Limit of items to return, required with offset; 50 by default and at most 100 with a cursor"
+// @Param offset query int false "Offset of items to return"
+// @Param cursor query string false "Cursor from the next or prev field of the previous page"
+// @Param dir query string false "Direction of the date order of a new cursor listing, asc by default" Enums(asc, desc)
+// @Param tag query []string false "Only items with these tags" collectionFormat(multi)
+// @Param tag_mode query string false "any (default) or all of the tags" Enums(any, all)
+// @Success 200 {object} cursorTodoItemsResponse
 // @Failure 400 {object} errorResponse
 // @Failure 404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /api/todo/done [get]
 func (h *Handler) GetDoneTodoItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if _, ok := c.GetQuery("offset"); !ok {
+		h.getTodoItemsByCursor(c, userId, true)
+		return
+	}
 	dateStr := c.Query("date")
 	limitStr := c.Query("limit")
 	offsetStr := c.Query("offset")
@@ -185,9 +544,15 @@ func (h *Handler) GetDoneTodoItems(c *gin.Context) {
 		return
 	}
 
-	todos, err := h.services.GetDoneTodoItems(date, limit, offset)
+	filter, err := parseTagFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	todos, err := h.services.TodoItem.GetDoneTodoItems(c.Request.Context(), userId, date, limit, offset, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
 
@@ -196,21 +561,38 @@ func (h *Handler) GetDoneTodoItems(c *gin.Context) {
 
 // GetUndoneTodoItems
 // @Tags get by is_done
+// @Security ApiKeyAuth
 // @Summary getUndoneTodoItems
 // @Description get undone todos by date with pagination
 // @ID get-undone-todo-items
 // @Accept  json
 // @Produce  json
+// @Description Without offset the todos are paged by an opaque cursor in (date, id) order and
+// @Description the response is an object with data and the next and prev cursors. With limit
+// @Description and offset the response is a plain array of todos.
 // @Param date query string false "Date in format YYYY-MM-DD"
-// @Param limit query int true "Limit of items to return"
-// @Param offset query int true "Offset of items to return"
-// @Success 200 {array} todoListSber.TodoItem
+// @Param limit query int false "Limit of items to return, required with offset; 50 by default and at most 100 with a cursor"
+// @Param offset query int false "Offset of items to return"
+// @Param cursor query string false "Cursor from the next or prev field of the previous page"
+// @Param dir query string false "Direction of the date order of a new cursor listing, asc by default" Enums(asc, desc)
+// @Param tag query []string false "Only items with these tags" collectionFormat(multi)
+// @Param tag_mode query string false "any (default) or all of the tags" Enums(any, all)
+// @Success 200 {object} cursorTodoItemsResponse
 // @Failure 400 {object} errorResponse
 // @Failure 404 {object} errorResponse
 // @Failure 500 {object} errorResponse
 // @Failure default {object} errorResponse
 // @Router /api/todo/undone [get]
 func (h *Handler) GetUndoneTodoItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if _, ok := c.GetQuery("offset"); !ok {
+		h.getTodoItemsByCursor(c, userId, false)
+		return
+	}
 	dateStr := c.Query("date")
 	limitStr := c.Query("limit")
 	offsetStr := c.Query("offset")
@@ -237,11 +619,77 @@ func (h *Handler) GetUndoneTodoItems(c *gin.Context) {
 		return
 	}
 
-	todos, err := h.services.GetUndoneTodoItems(date, limit, offset)
+	filter, err := parseTagFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	todos, err := h.services.TodoItem.GetUndoneTodoItems(c.Request.Context(), userId, date, limit, offset, filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		newServiceErrorResponse(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, todos)
 }
+
+// getTodoItemsByCursor serves /done and /undone when no offset is given.
+func (h *Handler) getTodoItemsByCursor(c *gin.Context, userId int, isDone bool) {
+	query := todoListSber.TodoItemQuery{IsDone: &isDone, Limit: todoListSber.DefaultPageLimit}
+
+	var err error
+	if query.From, err = parseDay(c, "date"); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	query.To = query.From
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > todoListSber.MaxPageLimit {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		query.Limit = limit
+	}
+
+	switch c.Query("dir") {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		newErrorResponse(c, http.StatusBadRequest, "Invalid dir")
+		return
+	}
+
+	var cursor *todoListSber.ItemCursor
+	if token := c.Query("cursor"); token != "" {
+		decoded, err := todoListSber.DecodeItemCursor(token)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		cursor = &decoded
+	}
+
+	if query.Tags, err = parseTagFilter(c); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.services.TodoItem.GetByCursor(c.Request.Context(), userId, query, cursor)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+
+	response := cursorTodoItemsResponse{Data: page.Items}
+	if page.Next != nil {
+		response.Next = page.Next.Encode()
+	}
+	if page.Prev != nil {
+		response.Prev = page.Prev.Encode()
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -16,6 +16,11 @@ import (
 	servicemocks "todo-list-sber/pkg/service/mocks"
 )
 
+// withUser stands in for the userIdentity middleware and authenticates every request as user 1.
+func withUser(c *gin.Context) {
+	c.Set(userCtx, 1)
+}
+
 func TestCreateTodoItemHandler(t *testing.T) {
 	type mockBehavior func(r *servicemocks.MockTodoItem, item todoListSber.TodoItem)
 
@@ -37,7 +42,7 @@ func TestCreateTodoItemHandler(t *testing.T) {
 				IsDone:      true,
 			},
 			mockBehavior: func(r *servicemocks.MockTodoItem, item todoListSber.TodoItem) {
-				r.EXPECT().Create(item).Return(1, nil)
+				r.EXPECT().Create(gomock.Any(), 1, item).Return(1, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: `{"id":1}`,
@@ -60,10 +65,10 @@ func TestCreateTodoItemHandler(t *testing.T) {
 				IsDone:      true,
 			},
 			mockBehavior: func(r *servicemocks.MockTodoItem, item todoListSber.TodoItem) {
-				r.EXPECT().Create(item).Return(0, errors.New("Something went wrong"))
+				r.EXPECT().Create(gomock.Any(), 1, item).Return(0, errors.New("Something went wrong"))
 			},
 			expectedStatusCode:   500,
-			expectedResponseBody: `{"error":"Something went wrong"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -76,10 +81,10 @@ func TestCreateTodoItemHandler(t *testing.T) {
 			test.mockBehavior(mockTodoItem, test.inputItem)
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			router := gin.New()
-			router.POST("/api/todo", handler.createTodoItem)
+			router.POST("/api/todo", withUser, handler.createTodoItem)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/api/todo",
@@ -93,31 +98,100 @@ func TestCreateTodoItemHandler(t *testing.T) {
 	}
 }
 func TestGetAllTodoItemsHandler(t *testing.T) {
+	isDone := true
+	from := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name                 string
+		url                  string
 		mockBehavior         func(r *servicemocks.MockTodoItem)
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		{
 			name: "Success",
+			url:  "/api/todo",
 			mockBehavior: func(r *servicemocks.MockTodoItem) {
 				expectedTodoItems := []todoListSber.TodoItem{
 					{Id: 1, Title: "Task 1", Description: "Description 1", Date: time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC), IsDone: false},
 					{Id: 2, Title: "Task 2", Description: "Description 2", Date: time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC), IsDone: false},
 				}
-				r.EXPECT().GetAll().Return(expectedTodoItems, nil)
+				r.EXPECT().GetAll(gomock.Any(), 1, todoListSber.TodoItemQuery{Limit: todoListSber.DefaultPageLimit}).
+					Return(todoListSber.TodoItemPage{Items: expectedTodoItems, Total: 2}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Task 1","description":"Description 1","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0},{"id":2,"title":"Task 2","description":"Description 2","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0}],"pagination":{"total":2,"limit":50,"offset":0}}`,
+		},
+		{
+			name: "Query",
+			url:  "/api/todo?status=done&from=2024-06-01&to=2024-06-30&text=report&sort=date&dir=desc&limit=10&offset=20",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.TodoItemQuery{
+					IsDone: &isDone,
+					From:   &from,
+					To:     &to,
+					Text:   "report",
+					Sort:   todoListSber.SortByDate,
+					Desc:   true,
+					Limit:  10,
+					Offset: 20,
+				}
+				r.EXPECT().GetAll(gomock.Any(), 1, query).Return(todoListSber.TodoItemPage{Total: 3}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"data":[{"id":1,"title":"Task 1","description":"Description 1","date":"2024-06-05T20:00:00Z","is_done":false},{"id":2,"title":"Task 2","description":"Description 2","date":"2024-06-05T20:00:00Z","is_done":false}]}`,
+			expectedResponseBody: `{"data":null,"pagination":{"total":3,"limit":10,"offset":20}}`,
+		},
+		{
+			name: "Ready",
+			url:  "/api/todo?status=ready",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetAll(gomock.Any(), 1, todoListSber.TodoItemQuery{Ready: true, Limit: todoListSber.DefaultPageLimit}).
+					Return(todoListSber.TodoItemPage{Total: 0}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":null,"pagination":{"total":0,"limit":50,"offset":0}}`,
+		},
+		{
+			name:                 "Invalid Status",
+			url:                  "/api/todo?status=pending",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid status"}`,
+		},
+		{
+			name:                 "Invalid Date",
+			url:                  "/api/todo?to=30.06.2024",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid to date format"}`,
+		},
+		{
+			name:                 "Limit Too Large",
+			url:                  "/api/todo?limit=101",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid limit"}`,
+		},
+		{
+			name: "Invalid Sort",
+			url:  "/api/todo?sort=color",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetAll(gomock.Any(), 1, todoListSber.TodoItemQuery{Sort: "color", Limit: todoListSber.DefaultPageLimit}).
+					Return(todoListSber.TodoItemPage{}, &todoListSber.ValidationError{Message: "sort must be one of priority, date, title, id"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"sort must be one of priority, date, title, id"}`,
 		},
 		{
 			name: "Service Error",
+			url:  "/api/todo",
 			mockBehavior: func(r *servicemocks.MockTodoItem) {
-				r.EXPECT().GetAll().Return(nil, errors.New("Service error"))
+				r.EXPECT().GetAll(gomock.Any(), 1, todoListSber.TodoItemQuery{Limit: todoListSber.DefaultPageLimit}).
+					Return(todoListSber.TodoItemPage{}, errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -130,12 +204,12 @@ func TestGetAllTodoItemsHandler(t *testing.T) {
 			test.mockBehavior(mockTodoItem)
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.GET("api/todo", handler.getAllTodoItems)
+			r.GET("api/todo", withUser, handler.getAllTodoItems)
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/api/todo", nil)
+			req := httptest.NewRequest("GET", test.url, nil)
 
 			r.ServeHTTP(w, req)
 
@@ -153,8 +227,10 @@ func TestGetTodoItemByIdHandler(t *testing.T) {
 	tests := []struct {
 		name                 string
 		idParam              string
+		ifNoneMatch          string
 		mockBehavior         func(r *servicemocks.MockTodoItem, id int)
 		expectedStatusCode   int
+		expectedETag         string
 		expectedResponseBody string
 	}{
 		{
@@ -167,11 +243,55 @@ func TestGetTodoItemByIdHandler(t *testing.T) {
 					Description: "Description 1",
 					Date:        time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC),
 					IsDone:      false,
+					Version:     3,
 				}
-				r.EXPECT().GetById(id).Return(expectedTodoItem, nil)
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(expectedTodoItem, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"data":{"id":1,"title":"Task 1","description":"Description 1","date":"2024-06-05T20:00:00Z","is_done":false}}`,
+			expectedETag:         `"3-af0a30d51e6c1e67"`,
+			expectedResponseBody: `{"data":{"id":1,"title":"Task 1","description":"Description 1","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0,"version":3}}`,
+		},
+		{
+			name:        "Not Modified",
+			idParam:     "1",
+			ifNoneMatch: `"2-e52fba51b39f1728", W/"3-e52fba51b39f1728"`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(todoListSber.TodoItem{Id: 1, Title: "Task 1", Version: 3}, nil)
+			},
+			expectedStatusCode:   http.StatusNotModified,
+			expectedETag:         `"3-e52fba51b39f1728"`,
+			expectedResponseBody: ``,
+		},
+		{
+			name:        "Modified",
+			idParam:     "1",
+			ifNoneMatch: `"2-e52fba51b39f1728"`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(todoListSber.TodoItem{Id: 1, Title: "Task 1", Version: 3}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedETag:         `"3-e52fba51b39f1728"`,
+			expectedResponseBody: `{"data":{"id":1,"title":"Task 1","description":"","date":"0001-01-01T00:00:00Z","is_done":false,"priority":0,"version":3}}`,
+		},
+		{
+			name:        "Subtasks Changed",
+			idParam:     "1",
+			ifNoneMatch: `"3-e52fba51b39f1728"`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				item := todoListSber.TodoItem{Id: 1, Title: "Task 1", Subtasks: &todoListSber.SubtaskCounts{Completed: 1, Total: 2}, Version: 3}
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(item, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":{"id":1,"title":"Task 1","description":"","date":"0001-01-01T00:00:00Z","is_done":false,"priority":0,"subtasks":{"completed":1,"total":2},"version":3}}`,
+		},
+		{
+			name:    "Not Found",
+			idParam: "2",
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(todoListSber.TodoItem{}, todoListSber.ErrTodoItemNotFound(id))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
 		},
 		{
 			name:                 "Invalid ID",
@@ -183,10 +303,10 @@ func TestGetTodoItemByIdHandler(t *testing.T) {
 		{
 			name: "Service Error",
 			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
-				r.EXPECT().GetAll().Return(nil, errors.New("Service error"))
+				r.EXPECT().GetById(gomock.Any(), 1, id).Return(todoListSber.TodoItem{}, errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -207,18 +327,24 @@ func TestGetTodoItemByIdHandler(t *testing.T) {
 			test.mockBehavior(mockTodoItem, id)
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.GET("/api/todo/:id", handler.getTodoItemById)
+			r.GET("/api/todo/:id", withUser, handler.getTodoItemById)
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", "/api/todo/"+test.idParam, nil)
+			if test.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", test.ifNoneMatch)
+			}
 
 			r.ServeHTTP(w, req)
 
 			if w.Code != test.expectedStatusCode {
 				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
 			}
+			if etag := w.Header().Get("ETag"); test.expectedETag != "" && etag != test.expectedETag {
+				t.Errorf("expected ETag %s; got %q", test.expectedETag, etag)
+			}
 
 			if w.Body.String() != test.expectedResponseBody {
 				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
@@ -228,71 +354,140 @@ func TestGetTodoItemByIdHandler(t *testing.T) {
 
 }
 func TestUpdateTodoItemHandler(t *testing.T) {
+	date := time.Date(2024, time.June, 7, 20, 0, 0, 0, time.UTC)
+	fullBody := `{"title": "Updated Task", "description": "Updated Description", "date": "2024-06-07T20:00:00Z", "is_done": true}`
+	fullFields := todoListSber.ItemFields{Title: "Updated Task", Description: "Updated Description", Date: &date, IsDone: true}
+
 	tests := []struct {
 		name                 string
 		idParam              string
+		query                string
+		ifMatch              string
 		inputBody            string
-		mockBehavior         func(r *servicemocks.MockTodoItem, id int, input todoListSber.UpdateItemInput)
+		mockBehavior         func(r *servicemocks.MockTodoItem, id int)
 		expectedStatusCode   int
 		expectedResponseBody string
 	}{
 		{
 			name:      "Success",
 			idParam:   "1",
-			inputBody: `{"title": "Updated Task", "description": "Updated Description", "date": "2024-06-07T20:00:00Z", "is_done": true}`,
-			mockBehavior: func(r *servicemocks.MockTodoItem, id int, input todoListSber.UpdateItemInput) {
-				title := "Updated Task"
-				description := "Updated Description"
-				isDone := true
-				date := time.Date(2024, time.June, 7, 20, 0, 0, 0, time.UTC)
-
-				input = todoListSber.UpdateItemInput{
-					Title:       &title,
-					Description: &description,
-					IsDone:      &isDone,
-					Date:        &date,
-				}
-				r.EXPECT().Update(id, input).Return(nil)
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Replace(gomock.Any(), 1, id, fullFields).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "Cascade",
+			idParam:   "1",
+			query:     "?cascade=true",
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				fields := fullFields
+				fields.Cascade = true
+				r.EXPECT().Replace(gomock.Any(), 1, id, fields).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid Cascade",
+			idParam:              "1",
+			query:                "?cascade=maybe",
+			inputBody:            fullBody,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid cascade"}`,
+		},
+		{
+			name:      "If-Match",
+			idParam:   "1",
+			ifMatch:   `"3-af0a30d51e6c1e67"`,
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				fields, version := fullFields, 3
+				fields.Version = &version
+				r.EXPECT().Replace(gomock.Any(), 1, id, fields).Return(nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"ok"}`,
 		},
+		{
+			name:      "Stale Version",
+			idParam:   "1",
+			ifMatch:   `"2"`,
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				fields, version := fullFields, 2
+				fields.Version = &version
+				r.EXPECT().Replace(gomock.Any(), 1, id, fields).Return(todoListSber.ErrVersionMismatch(id, 3))
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"todo item 1 has been changed, its current version is 3"}`,
+		},
+		{
+			name:                 "Weak If-Match",
+			idParam:              "1",
+			ifMatch:              `W/"3"`,
+			inputBody:            fullBody,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"If-Match does not match any version"}`,
+		},
 		{
 			name:                 "Invalid ID",
 			idParam:              "invalid",
-			inputBody:            `{}`,
-			mockBehavior:         func(r *servicemocks.MockTodoItem, id int, input todoListSber.UpdateItemInput) {},
+			inputBody:            fullBody,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"error":"Invalid ID"}`,
 		},
+		{
+			name:                 "Empty Body",
+			idParam:              "1",
+			inputBody:            ``,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
 		{
 			name:                 "Invalid Input Body",
 			idParam:              "1",
-			inputBody:            `{}`,
-			mockBehavior:         func(r *servicemocks.MockTodoItem, id int, input todoListSber.UpdateItemInput) {},
+			inputBody:            `{"title": 1}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
 			expectedStatusCode:   http.StatusBadRequest,
-			expectedResponseBody: `{"error":"EOF"}`,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Missing Fields",
+			idParam:   "1",
+			inputBody: `{}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Replace(gomock.Any(), 1, id, todoListSber.ItemFields{}).Return(&todoListSber.ValidationError{Message: "date is required"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"date is required"}`,
+		},
+		{
+			name:      "Not Found",
+			idParam:   "2",
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Replace(gomock.Any(), 1, id, fullFields).Return(todoListSber.ErrTodoItemNotFound(id))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
 		},
 		{
 			name:      "Service Error",
 			idParam:   "1",
-			inputBody: `{"title": "Updated Task", "description": "Updated Description", "date": "2024-06-07T20:00:00Z", "is_done": true}`,
-			mockBehavior: func(r *servicemocks.MockTodoItem, id int, input todoListSber.UpdateItemInput) {
-				title := "Updated Task"
-				description := "Updated Description"
-				isDone := true
-				date := time.Date(2024, time.June, 7, 20, 0, 0, 0, time.UTC)
-
-				input = todoListSber.UpdateItemInput{
-					Title:       &title,
-					Description: &description,
-					IsDone:      &isDone,
-					Date:        &date,
-				}
-				r.EXPECT().Update(id, input).Return(errors.New("Service error"))
+			inputBody: fullBody,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Replace(gomock.Any(), 1, id, fullFields).Return(errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -301,25 +496,181 @@ func TestUpdateTodoItemHandler(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			id, err := strconv.Atoi(test.idParam)
-			if err != nil {
-				w := httptest.NewRecorder()
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(`{"error":"Invalid ID"}`))
-				return
+			id, _ := strconv.Atoi(test.idParam)
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem, id)
+
+			services := &service.Service{TodoItem: mockTodoItem}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.PUT("/api/todo/:id", withUser, handler.updateTodoItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/api/todo/"+test.idParam+test.query, bytes.NewBufferString(test.inputBody))
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
 			}
 
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPatchTodoItemHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		idParam              string
+		query                string
+		contentType          string
+		ifMatch              string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockTodoItem, id int)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectedAcceptPatch  string
+	}{
+		{
+			name:        "Merge Patch",
+			idParam:     "1",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"description": null}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				patch := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: []byte(`{"description": null}`)}
+				r.EXPECT().Patch(gomock.Any(), 1, id, patch).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:        "JSON Patch With Charset",
+			idParam:     "1",
+			contentType: "application/json-patch+json; charset=utf-8",
+			inputBody:   `[{"op": "add", "path": "/tags/-", "value": "work"}]`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				patch := todoListSber.ItemPatch{Format: todoListSber.JSONPatch, Document: []byte(`[{"op": "add", "path": "/tags/-", "value": "work"}]`)}
+				r.EXPECT().Patch(gomock.Any(), 1, id, patch).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:        "Cascade And If-Match",
+			idParam:     "1",
+			query:       "?cascade=true",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"4"`,
+			inputBody:   `{"is_done": true}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				version := 4
+				patch := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: []byte(`{"is_done": true}`), Cascade: true, Version: &version}
+				r.EXPECT().Patch(gomock.Any(), 1, id, patch).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Unsupported Content-Type",
+			idParam:              "1",
+			contentType:          "application/json",
+			inputBody:            `{"is_done": true}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusUnsupportedMediaType,
+			expectedResponseBody: `{"error":"Content-Type must be application/merge-patch+json or application/json-patch+json"}`,
+			expectedAcceptPatch:  "application/merge-patch+json, application/json-patch+json",
+		},
+		{
+			name:                 "Empty Body",
+			idParam:              "1",
+			contentType:          "application/merge-patch+json",
+			inputBody:            ` `,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:                 "Invalid ID",
+			idParam:              "invalid",
+			contentType:          "application/merge-patch+json",
+			inputBody:            `{"is_done": true}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid ID"}`,
+		},
+		{
+			name:                 "Weak If-Match",
+			idParam:              "1",
+			contentType:          "application/merge-patch+json",
+			ifMatch:              `W/"4"`,
+			inputBody:            `{"is_done": true}`,
+			mockBehavior:         func(r *servicemocks.MockTodoItem, id int) {},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"If-Match does not match any version"}`,
+		},
+		{
+			name:        "Failed Test",
+			idParam:     "1",
+			contentType: "application/json-patch+json",
+			inputBody:   `[{"op": "test", "path": "/title", "value": "Other"}]`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Patch(gomock.Any(), 1, id, gomock.Any()).Return(&todoListSber.ConflictError{Message: "operation 0: test operation failed: /title"})
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"operation 0: test operation failed: /title"}`,
+		},
+		{
+			name:        "Validation Error",
+			idParam:     "1",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"title": ""}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Patch(gomock.Any(), 1, id, gomock.Any()).Return(&todoListSber.ValidationError{Message: "title must not be empty"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"title must not be empty"}`,
+		},
+		{
+			name:        "Not Found",
+			idParam:     "2",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"is_done": true}`,
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Patch(gomock.Any(), 1, id, gomock.Any()).Return(todoListSber.ErrTodoItemNotFound(id))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			id, _ := strconv.Atoi(test.idParam)
 			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
-			test.mockBehavior(mockTodoItem, id, todoListSber.UpdateItemInput{})
+			test.mockBehavior(mockTodoItem, id)
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.PUT("/api/todo/:id", handler.updateTodoItem)
+			r.PATCH("/api/todo/:id", withUser, handler.patchTodoItem)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("PUT", "/api/todo/"+test.idParam, bytes.NewBufferString(test.inputBody))
+			req := httptest.NewRequest("PATCH", "/api/todo/"+test.idParam+test.query, bytes.NewBufferString(test.inputBody))
+			req.Header.Set("Content-Type", test.contentType)
+			if test.ifMatch != "" {
+				req.Header.Set("If-Match", test.ifMatch)
+			}
 
 			r.ServeHTTP(w, req)
 
@@ -330,9 +681,14 @@ func TestUpdateTodoItemHandler(t *testing.T) {
 			if w.Body.String() != test.expectedResponseBody {
 				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
 			}
+
+			if got := w.Header().Get("Accept-Patch"); got != test.expectedAcceptPatch {
+				t.Errorf("expected Accept-Patch %q; got %q", test.expectedAcceptPatch, got)
+			}
 		})
 	}
 }
+
 func TestDeleteTodoItemHandler(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -345,11 +701,20 @@ func TestDeleteTodoItemHandler(t *testing.T) {
 			name:    "Success",
 			idParam: "1",
 			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
-				r.EXPECT().Delete(id).Return(nil)
+				r.EXPECT().Delete(gomock.Any(), 1, id).Return(nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"ok"}`,
 		},
+		{
+			name:    "Not Found",
+			idParam: "2",
+			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
+				r.EXPECT().Delete(gomock.Any(), 1, id).Return(todoListSber.ErrTodoItemNotFound(id))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
+		},
 		{
 			name:                 "Invalid ID",
 			idParam:              "invalid",
@@ -361,10 +726,10 @@ func TestDeleteTodoItemHandler(t *testing.T) {
 			name:    "Service Error",
 			idParam: "1",
 			mockBehavior: func(r *servicemocks.MockTodoItem, id int) {
-				r.EXPECT().Delete(id).Return(errors.New("Service error"))
+				r.EXPECT().Delete(gomock.Any(), 1, id).Return(errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -385,10 +750,10 @@ func TestDeleteTodoItemHandler(t *testing.T) {
 			test.mockBehavior(mockTodoItem, id)
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.DELETE("/api/todo/:id", handler.deleteTodoItem)
+			r.DELETE("/api/todo/:id", withUser, handler.deleteTodoItem)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("DELETE", "/api/todo/"+test.idParam, nil)
@@ -434,10 +799,10 @@ func TestGetDoneTodoItemsHandler(t *testing.T) {
 						IsDone:      true,
 					},
 				}
-				r.EXPECT().GetDoneTodoItems(&expectedDate, 10, 0).Return(expectedTodos, nil)
+				r.EXPECT().GetDoneTodoItems(gomock.Any(), 1, &expectedDate, 10, 0, todoListSber.TagFilter{}).Return(expectedTodos, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[{"id":1,"title":"task 1","description":"description 1","date":"2024-06-08T00:00:00Z","is_done":true},{"id":2,"title":"task 2","description":"description 2","date":"2024-06-08T00:00:00Z","is_done":true}]`,
+			expectedResponseBody: `[{"id":1,"title":"task 1","description":"description 1","date":"2024-06-08T00:00:00Z","is_done":true,"priority":0},{"id":2,"title":"task 2","description":"description 2","date":"2024-06-08T00:00:00Z","is_done":true,"priority":0}]`,
 		},
 		{
 			name:                 "Invalid Date",
@@ -465,10 +830,10 @@ func TestGetDoneTodoItemsHandler(t *testing.T) {
 			queryParams: "?date=2024-06-08&limit=10&offset=0",
 			mockBehavior: func(r *servicemocks.MockTodoItem, date *time.Time, limit int, offset int) {
 				expectedDate, _ := time.Parse("2006-01-02", "2024-06-08")
-				r.EXPECT().GetDoneTodoItems(&expectedDate, 10, 0).Return(nil, errors.New("Service error"))
+				r.EXPECT().GetDoneTodoItems(gomock.Any(), 1, &expectedDate, 10, 0, todoListSber.TagFilter{}).Return(nil, errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -495,10 +860,10 @@ func TestGetDoneTodoItemsHandler(t *testing.T) {
 			}
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.GET("/api/todo/done", handler.GetDoneTodoItems)
+			r.GET("/api/todo/done", withUser, handler.GetDoneTodoItems)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", "/api/todo/done"+test.queryParams, nil)
@@ -544,10 +909,10 @@ func TestGetUndoneTodoItemsHandler(t *testing.T) {
 						IsDone:      false,
 					},
 				}
-				r.EXPECT().GetDoneTodoItems(&expectedDate, 10, 0).Return(expectedTodos, nil)
+				r.EXPECT().GetDoneTodoItems(gomock.Any(), 1, &expectedDate, 10, 0, todoListSber.TagFilter{}).Return(expectedTodos, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `[{"id":1,"title":"task 1","description":"description 1","date":"2024-06-08T00:00:00Z","is_done":false},{"id":2,"title":"task 2","description":"description 2","date":"2024-06-08T00:00:00Z","is_done":false}]`,
+			expectedResponseBody: `[{"id":1,"title":"task 1","description":"description 1","date":"2024-06-08T00:00:00Z","is_done":false,"priority":0},{"id":2,"title":"task 2","description":"description 2","date":"2024-06-08T00:00:00Z","is_done":false,"priority":0}]`,
 		},
 		{
 			name:                 "Invalid Date",
@@ -575,10 +940,10 @@ func TestGetUndoneTodoItemsHandler(t *testing.T) {
 			queryParams: "?date=2024-06-08&limit=10&offset=0",
 			mockBehavior: func(r *servicemocks.MockTodoItem, date *time.Time, limit int, offset int) {
 				expectedDate, _ := time.Parse("2006-01-02", "2024-06-08")
-				r.EXPECT().GetDoneTodoItems(&expectedDate, 10, 0).Return(nil, errors.New("Service error"))
+				r.EXPECT().GetDoneTodoItems(gomock.Any(), 1, &expectedDate, 10, 0, todoListSber.TagFilter{}).Return(nil, errors.New("Service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
-			expectedResponseBody: `{"error":"Service error"}`,
+			expectedResponseBody: `{"error":"Internal Server Error"}`,
 		},
 	}
 
@@ -605,10 +970,10 @@ func TestGetUndoneTodoItemsHandler(t *testing.T) {
 			}
 
 			services := &service.Service{TodoItem: mockTodoItem}
-			handler := Handler{services}
+			handler := Handler{services: services}
 
 			r := gin.New()
-			r.GET("/api/todo/done", handler.GetDoneTodoItems)
+			r.GET("/api/todo/done", withUser, handler.GetDoneTodoItems)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", "/api/todo/done"+test.queryParams, nil)
@@ -625,3 +990,243 @@ func TestGetUndoneTodoItemsHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTodoItemsByCursorHandler(t *testing.T) {
+	isDone := false
+	day := time.Date(2024, time.June, 8, 0, 0, 0, 0, time.UTC)
+	cursor := todoListSber.ItemCursor{Date: day, Id: 2}
+	next := todoListSber.ItemCursor{Date: day, Id: 3}
+	prev := todoListSber.ItemCursor{Date: day, Id: 3, Backward: true}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "First Page",
+			url:  "/api/todo/undone?date=2024-06-08&limit=1",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.TodoItemQuery{IsDone: &isDone, From: &day, To: &day, Limit: 1}
+				r.EXPECT().GetByCursor(gomock.Any(), 1, query, nil).
+					Return(todoListSber.TodoItemCursorPage{Items: []todoListSber.TodoItem{{Id: 2, Title: "Task 2", Date: day}}, Next: &cursor}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":2,"title":"Task 2","description":"","date":"2024-06-08T00:00:00Z","is_done":false,"priority":0}],"next":"` + cursor.Encode() + `"}`,
+		},
+		{
+			name: "Next Page",
+			url:  "/api/todo/undone?dir=desc&cursor=" + cursor.Encode(),
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.TodoItemQuery{IsDone: &isDone, Desc: true, Limit: todoListSber.DefaultPageLimit}
+				r.EXPECT().GetByCursor(gomock.Any(), 1, query, &cursor).
+					Return(todoListSber.TodoItemCursorPage{Next: &next, Prev: &prev}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":null,"next":"` + next.Encode() + `","prev":"` + prev.Encode() + `"}`,
+		},
+		{
+			name:                 "Invalid Cursor",
+			url:                  "/api/todo/undone?cursor=not-a-cursor",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid cursor"}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			url:                  "/api/todo/undone?limit=101",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid limit"}`,
+		},
+		{
+			name:                 "Invalid Dir",
+			url:                  "/api/todo/undone?dir=up",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid dir"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			services := &service.Service{TodoItem: mockTodoItem}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.GET("/api/todo/undone", withUser, handler.GetUndoneTodoItems)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSearchTodoItemsHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Success",
+			url:  "/api/todo/search?q=milk&lang=english&limit=10&offset=5",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				query := todoListSber.SearchQuery{Text: "milk", Language: todoListSber.SearchLanguageEnglish, Limit: 10, Offset: 5}
+				results := []todoListSber.SearchResult{{
+					TodoItem: todoListSber.TodoItem{Id: 1, Title: "Buy milk", Date: time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)},
+					Rank:     0.5,
+					Snippet:  "Buy <b>milk</b>",
+				}}
+				r.EXPECT().Search(gomock.Any(), 1, query).Return(results, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Buy milk","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0,"rank":0.5,"snippet":"Buy \u003cb\u003emilk\u003c/b\u003e"}]}`,
+		},
+		{
+			name: "Empty Query",
+			url:  "/api/todo/search",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Search(gomock.Any(), 1, todoListSber.SearchQuery{Limit: todoListSber.DefaultPageLimit}).
+					Return(nil, &todoListSber.ValidationError{Message: "search query must not be empty"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"search query must not be empty"}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			url:                  "/api/todo/search?q=milk&limit=0",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid limit"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			services := &service.Service{TodoItem: mockTodoItem}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.GET("/api/todo/search", withUser, handler.searchTodoItems)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetTodoItemOccurrencesHandler(t *testing.T) {
+	from := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Success",
+			url:  "/api/todo/1/occurrences?from=2024-06-01&to=2024-06-30&limit=2",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetOccurrences(gomock.Any(), 1, 1, from, to, 2).Return([]time.Time{
+					time.Date(2024, time.June, 3, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+					time.Date(2024, time.June, 10, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60)),
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":["2024-06-03T09:00:00+03:00","2024-06-10T09:00:00+03:00"]}`,
+		},
+		{
+			name: "Default Range",
+			url:  "/api/todo/1/occurrences?from=2024-06-01",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetOccurrences(gomock.Any(), 1, 1, from, from.AddDate(0, 0, 30), todoListSber.DefaultPageLimit).
+					Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":null}`,
+		},
+		{
+			name:                 "Invalid Date",
+			url:                  "/api/todo/1/occurrences?to=tomorrow",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid to date format"}`,
+		},
+		{
+			name: "Not Found",
+			url:  "/api/todo/1/occurrences?from=2024-06-01&to=2024-06-30",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetOccurrences(gomock.Any(), 1, 1, from, to, todoListSber.DefaultPageLimit).
+					Return(nil, todoListSber.ErrTodoItemNotFound(1))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 1 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			services := &service.Service{TodoItem: mockTodoItem}
+			handler := Handler{services: services}
+
+			r := gin.New()
+			r.GET("/api/todo/:id/occurrences", withUser, handler.getTodoItemOccurrences)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != test.expectedStatusCode {
+				t.Errorf("expected status %d; got %d", test.expectedStatusCode, w.Code)
+			}
+
+			if w.Body.String() != test.expectedResponseBody {
+				t.Errorf("expected response body %q; got %q", test.expectedResponseBody, w.Body.String())
+			}
+		})
+	}
+}
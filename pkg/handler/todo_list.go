@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary createTodoList
+// @Description create todo list
+// @ID create-todo-list
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.TodoList true "list info"
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists [post]
+func (h *Handler) createTodoList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var input todoListSber.TodoList
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	id, err := h.services.TodoList.Create(c.Request.Context(), userId, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary getAllTodoLists
+// @Description get all todo lists
+// @ID get-all-todo-lists
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} todoListSber.TodoList
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists [get]
+func (h *Handler) getAllTodoLists(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	lists, err := h.services.TodoList.GetAll(c.Request.Context(), userId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": lists})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary getTodoListById
+// @Description get todo list by id
+// @ID get-todo-list-by-id
+// @Param id path string true "list id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} todoListSber.TodoList
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id} [get]
+func (h *Handler) getTodoListById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	list, err := h.services.TodoList.GetById(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": list})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary updateTodoList
+// @Description update todo list
+// @ID update-todo-list
+// @Param id path string true "list id"
+// @Param input body todoListSber.UpdateListInput true "list info"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id} [put]
+func (h *Handler) updateTodoList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var input todoListSber.UpdateListInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	err = h.services.TodoList.Update(c.Request.Context(), userId, id, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary deleteTodoList
+// @Description delete todo list. Its items are kept without a list unless cascade=true, which moves them to the trash
+// @ID delete-todo-list
+// @Param id path string true "list id"
+// @Param cascade query bool false "Move the items of the list to the trash as well"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id} [delete]
+func (h *Handler) deleteTodoList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	cascade := false
+	if cascadeStr := c.Query("cascade"); cascadeStr != "" {
+		cascade, err = strconv.ParseBool(cascadeStr)
+		if err != nil {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid cascade")
+			return
+		}
+	}
+	err = h.services.TodoList.Delete(c.Request.Context(), userId, id, cascade)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary getTodoListItems
+// @Description get the todo items of a list ordered by date
+// @ID get-todo-list-items
+// @Param id path string true "list id"
+// @Param tag query []string false "Only items with these tags" collectionFormat(multi)
+// @Param tag_mode query string false "any (default) or all of the tags" Enums(any, all)
+// @Param limit query int false "Limit of items to return, 50 by default and at most 100"
+// @Param offset query int false "Offset of items to return"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllTodoItemsResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id}/items [get]
+func (h *Handler) getTodoListItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	filter, err := parseTagFilter(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit, offset, err := parsePage(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	page, err := h.services.TodoItem.GetByList(c.Request.Context(), userId, id, filter, limit, offset)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getAllTodoItemsResponse{
+		Data:       page.Items,
+		Pagination: pagination{Total: page.Total, Limit: limit, Offset: offset},
+	})
+}
+
+// @Tags lists
+// @Security ApiKeyAuth
+// @Summary createTodoListItem
+// @Description create todo item in a list date example: 2024-06-07T12:00:00Z
+// @ID create-todo-list-item
+// @Param id path string true "list id"
+// @Param input body todoListSber.TodoItem true "todo info"
+// @Accept  json
+// @Produce  json
+// @Success 200 {integer} integer 1
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/lists/{id}/items [post]
+func (h *Handler) createTodoListItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var input todoListSber.TodoItem
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	input.ListId = &id
+	itemId, err := h.services.TodoItem.Create(c.Request.Context(), userId, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": itemId})
+}
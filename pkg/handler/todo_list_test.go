@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestCreateTodoListHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockTodoList)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"title": "Work", "description": "Office tasks"}`,
+			mockBehavior: func(r *servicemocks.MockTodoList) {
+				r.EXPECT().Create(gomock.Any(), 1, todoListSber.TodoList{Title: "Work", Description: "Office tasks"}).Return(1, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1}`,
+		},
+		{
+			name:                 "Wrong Input",
+			inputBody:            `{}`,
+			mockBehavior:         func(r *servicemocks.MockTodoList) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoList := servicemocks.NewMockTodoList(ctrl)
+			test.mockBehavior(mockTodoList)
+
+			handler := Handler{services: &service.Service{TodoList: mockTodoList}}
+
+			router := gin.New()
+			router.POST("/api/lists", withUser, handler.createTodoList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/lists", bytes.NewBufferString(test.inputBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteTodoListHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoList)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Keep Items",
+			url:  "/api/lists/1",
+			mockBehavior: func(r *servicemocks.MockTodoList) {
+				r.EXPECT().Delete(gomock.Any(), 1, 1, false).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name: "Cascade",
+			url:  "/api/lists/1?cascade=true",
+			mockBehavior: func(r *servicemocks.MockTodoList) {
+				r.EXPECT().Delete(gomock.Any(), 1, 1, true).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid Cascade",
+			url:                  "/api/lists/1?cascade=maybe",
+			mockBehavior:         func(r *servicemocks.MockTodoList) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid cascade"}`,
+		},
+		{
+			name: "Not Found",
+			url:  "/api/lists/2",
+			mockBehavior: func(r *servicemocks.MockTodoList) {
+				r.EXPECT().Delete(gomock.Any(), 1, 2, false).Return(todoListSber.ErrTodoListNotFound(2))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo list with id 2 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoList := servicemocks.NewMockTodoList(ctrl)
+			test.mockBehavior(mockTodoList)
+
+			handler := Handler{services: &service.Service{TodoList: mockTodoList}}
+
+			router := gin.New()
+			router.DELETE("/api/lists/:id", withUser, handler.deleteTodoList)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestTodoListItemsHandlers(t *testing.T) {
+	listId := 3
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+	mockTodoItem.EXPECT().Create(gomock.Any(), 1, todoListSber.TodoItem{Title: "Report", Date: date, ListId: &listId}).Return(7, nil)
+	mockTodoItem.EXPECT().GetByList(gomock.Any(), 1, listId, todoListSber.TagFilter{}, todoListSber.DefaultPageLimit, 0).Return(todoListSber.TodoItemPage{
+		Items: []todoListSber.TodoItem{{Id: 7, Title: "Report", Date: date, ListId: &listId}},
+		Total: 3,
+	}, nil)
+	mockTodoItem.EXPECT().GetByList(gomock.Any(), 1, listId, todoListSber.TagFilter{}, 2, 2).Return(todoListSber.TodoItemPage{Total: 3}, nil)
+	mockTodoItem.EXPECT().GetByList(gomock.Any(), 1, 4, todoListSber.TagFilter{}, todoListSber.DefaultPageLimit, 0).Return(todoListSber.TodoItemPage{}, todoListSber.ErrTodoListNotFound(4))
+
+	handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+	router := gin.New()
+	router.POST("/api/lists/:id/items", withUser, handler.createTodoListItem)
+	router.GET("/api/lists/:id/items", withUser, handler.getTodoListItems)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/lists/3/items",
+		bytes.NewBufferString(`{"title": "Report", "date": "2024-06-05T20:00:00Z", "list_id": 9}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"id":7}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/lists/3/items", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":[{"id":7,"title":"Report","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":0,"list_id":3}],"pagination":{"total":3,"limit":50,"offset":0}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/lists/3/items?limit=2&offset=2", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"data":null,"pagination":{"total":3,"limit":2,"offset":2}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/lists/3/items?limit=500", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, `{"error":"Invalid limit"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/lists/4/items", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"error":"todo list with id 4 not found"}`, w.Body.String())
+}
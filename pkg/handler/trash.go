@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getTrashResponse struct {
+	Data []todoListSber.TodoItem `json:"data"`
+}
+
+// @Tags trash
+// @Security ApiKeyAuth
+// @Summary getTrash
+// @Description get the deleted todo items, the most recently deleted first. They are purged for good once the retention configured on the server runs out
+// @ID get-trash
+// @Param limit query int false "Page size, 50 by default and at most 100"
+// @Param offset query int false "Number of items to skip"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getTrashResponse
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/trash [get]
+func (h *Handler) getTrash(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	limit, offset := todoListSber.DefaultPageLimit, 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > todoListSber.MaxPageLimit {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+	}
+
+	items, err := h.services.TodoItem.GetTrash(c.Request.Context(), userId, limit, offset)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getTrashResponse{Data: items})
+}
+
+// @Tags trash
+// @Security ApiKeyAuth
+// @Summary restoreTodoItem
+// @Description restore a deleted todo item together with the subtasks deleted with it. Fails with 409 while its parent is in the trash
+// @ID restore-todo-item
+// @Param id path string true "todo item id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404,409 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/todo/{id}/restore [post]
+func (h *Handler) restoreTodoItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	if err := h.services.TodoItem.Restore(c.Request.Context(), userId, id); err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
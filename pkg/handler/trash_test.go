@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestGetTrashHandler(t *testing.T) {
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+	deletedAt := date.Add(time.Hour)
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/todo/trash",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetTrash(gomock.Any(), 1, todoListSber.DefaultPageLimit, 0).Return([]todoListSber.TodoItem{
+					{Id: 1, Title: "Deleted", Date: date, Priority: 4, DeletedAt: &deletedAt},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":1,"title":"Deleted","description":"","date":"2024-06-05T20:00:00Z","is_done":false,"priority":4,"deleted_at":"2024-06-05T21:00:00Z"}]}`,
+		},
+		{
+			name: "Page",
+			url:  "/api/todo/trash?limit=10&offset=20",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().GetTrash(gomock.Any(), 1, 10, 20).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":null}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			url:                  "/api/todo/trash?limit=1000",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid limit"}`,
+		},
+		{
+			name:                 "Invalid Offset",
+			url:                  "/api/todo/trash?offset=-1",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid offset"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+			router := gin.New()
+			router.GET("/api/todo/trash", withUser, handler.getTrash)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestRestoreTodoItemHandler(t *testing.T) {
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockTodoItem)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/todo/1/restore",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Restore(gomock.Any(), 1, 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"ok"}`,
+		},
+		{
+			name:                 "Invalid ID",
+			url:                  "/api/todo/one/restore",
+			mockBehavior:         func(r *servicemocks.MockTodoItem) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid ID"}`,
+		},
+		{
+			name: "Not In Trash",
+			url:  "/api/todo/2/restore",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Restore(gomock.Any(), 1, 2).Return(todoListSber.ErrTodoItemNotFound(2))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"todo item with id 2 not found"}`,
+		},
+		{
+			name: "Parent In Trash",
+			url:  "/api/todo/3/restore",
+			mockBehavior: func(r *servicemocks.MockTodoItem) {
+				r.EXPECT().Restore(gomock.Any(), 1, 3).Return(todoListSber.ErrParentInTrash)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"parent item is in the trash, restore it first"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTodoItem := servicemocks.NewMockTodoItem(ctrl)
+			test.mockBehavior(mockTodoItem)
+
+			handler := Handler{services: &service.Service{TodoItem: mockTodoItem}}
+
+			router := gin.New()
+			router.POST("/api/todo/:id/restore", withUser, handler.restoreTodoItem)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	todoListSber "todo-list-sber"
+)
+
+type getAllWebhooksResponse struct {
+	Data []todoListSber.Webhook `json:"data"`
+}
+
+type getWebhookDeliveriesResponse struct {
+	Data []todoListSber.WebhookDelivery `json:"data"`
+}
+
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Summary createWebhook
+// @Description register webhook receiving signed item events; the response holds the secret, which is not shown again
+// @ID create-webhook
+// @Accept  json
+// @Produce  json
+// @Param input body todoListSber.Webhook true "webhook info"
+// @Success 200 {object} todoListSber.Webhook
+// @Failure 400 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks [post]
+func (h *Handler) createWebhook(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var input todoListSber.Webhook
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid input body")
+		return
+	}
+	webhook, err := h.services.Webhook.Create(c.Request.Context(), userId, input)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Summary getAllWebhooks
+// @Description get all webhooks
+// @ID get-all-webhooks
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getAllWebhooksResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks [get]
+func (h *Handler) getAllWebhooks(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	webhooks, err := h.services.Webhook.GetAll(c.Request.Context(), userId)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getAllWebhooksResponse{Data: webhooks})
+}
+
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Summary getWebhookById
+// @Description get webhook by id
+// @ID get-webhook-by-id
+// @Param id path string true "webhook id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} todoListSber.Webhook
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks/{id} [get]
+func (h *Handler) getWebhookById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	webhook, err := h.services.Webhook.GetById(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Summary deleteWebhook
+// @Description delete webhook together with its delivery log
+// @ID delete-webhook
+// @Param id path string true "webhook id"
+// @Accept  json
+// @Produce  json
+// @Success 200 {string} status ok
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks/{id} [delete]
+func (h *Handler) deleteWebhook(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	err = h.services.Webhook.Delete(c.Request.Context(), userId, id)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// @Tags webhooks
+// @Security ApiKeyAuth
+// @Summary getWebhookDeliveries
+// @Description get delivery log of webhook, newest first
+// @ID get-webhook-deliveries
+// @Param id path string true "webhook id"
+// @Param limit query int false "page size, 50 by default and at most 100"
+// @Param offset query int false "number of deliveries to skip"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} getWebhookDeliveriesResponse
+// @Failure 400,404 {object} errorResponse
+// @Failure 500 {object} errorResponse
+// @Failure default {object} errorResponse
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *Handler) getWebhookDeliveries(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	limit, offset := todoListSber.DefaultPageLimit, 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > todoListSber.MaxPageLimit {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			newErrorResponse(c, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+	}
+
+	deliveries, err := h.services.Webhook.GetDeliveries(c.Request.Context(), userId, id, limit, offset)
+	if err != nil {
+		newServiceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, getWebhookDeliveriesResponse{Data: deliveries})
+}
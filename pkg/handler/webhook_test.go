@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/magiconair/properties/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/service"
+	servicemocks "todo-list-sber/pkg/service/mocks"
+)
+
+func TestCreateWebhookHandler(t *testing.T) {
+	createdAt := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                 string
+		inputBody            string
+		mockBehavior         func(r *servicemocks.MockWebhook)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"url": "https://example.com/hook", "events": ["item.completed"]}`,
+			mockBehavior: func(r *servicemocks.MockWebhook) {
+				input := todoListSber.Webhook{URL: "https://example.com/hook", Events: []string{"item.completed"}}
+				created := input
+				created.Id, created.Secret, created.CreatedAt = 1, "0123456789abcdef", createdAt
+				r.EXPECT().Create(gomock.Any(), 1, input).Return(created, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":1,"url":"https://example.com/hook","events":["item.completed"],"secret":"0123456789abcdef","created_at":"2024-06-05T12:00:00Z"}`,
+		},
+		{
+			name:                 "Missing URL",
+			inputBody:            `{"events": ["item.completed"]}`,
+			mockBehavior:         func(r *servicemocks.MockWebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid input body"}`,
+		},
+		{
+			name:      "Unknown Event",
+			inputBody: `{"url": "https://example.com/hook", "events": ["item.archived"]}`,
+			mockBehavior: func(r *servicemocks.MockWebhook) {
+				input := todoListSber.Webhook{URL: "https://example.com/hook", Events: []string{"item.archived"}}
+				r.EXPECT().Create(gomock.Any(), 1, input).Return(todoListSber.Webhook{}, &todoListSber.ValidationError{Message: "unknown event item.archived"})
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"unknown event item.archived"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebhook := servicemocks.NewMockWebhook(ctrl)
+			test.mockBehavior(mockWebhook)
+
+			handler := Handler{services: &service.Service{Webhook: mockWebhook}}
+
+			router := gin.New()
+			router.POST("/api/webhooks", withUser, handler.createWebhook)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/webhooks", bytes.NewBufferString(test.inputBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func TestGetWebhookDeliveriesHandler(t *testing.T) {
+	createdAt := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	status := 503
+	tests := []struct {
+		name                 string
+		url                  string
+		mockBehavior         func(r *servicemocks.MockWebhook)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/webhooks/1/deliveries?limit=10&offset=5",
+			mockBehavior: func(r *servicemocks.MockWebhook) {
+				r.EXPECT().GetDeliveries(gomock.Any(), 1, 1, 10, 5).Return([]todoListSber.WebhookDelivery{{
+					Id: 7, WebhookId: 1, Event: "item.deleted", Payload: json.RawMessage(`{"event":"item.deleted"}`),
+					Status: "failed", Attempts: 8, LastAttemptAt: &createdAt, ResponseStatus: &status,
+					Error: "webhook responded with status 503", CreatedAt: createdAt,
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":[{"id":7,"webhook_id":1,"event":"item.deleted","payload":{"event":"item.deleted"},"status":"failed","attempts":8,"last_attempt_at":"2024-06-05T12:00:00Z","response_status":503,"error":"webhook responded with status 503","created_at":"2024-06-05T12:00:00Z"}]}`,
+		},
+		{
+			name:                 "Invalid Limit",
+			url:                  "/api/webhooks/1/deliveries?limit=1000",
+			mockBehavior:         func(r *servicemocks.MockWebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"Invalid limit"}`,
+		},
+		{
+			name: "Not Found",
+			url:  "/api/webhooks/2/deliveries",
+			mockBehavior: func(r *servicemocks.MockWebhook) {
+				r.EXPECT().GetDeliveries(gomock.Any(), 1, 2, todoListSber.DefaultPageLimit, 0).Return(nil, todoListSber.ErrWebhookNotFound(2))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"webhook with id 2 not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWebhook := servicemocks.NewMockWebhook(ctrl)
+			test.mockBehavior(mockWebhook)
+
+			handler := Handler{services: &service.Service{Webhook: mockWebhook}}
+
+			router := gin.New()
+			router.GET("/api/webhooks/:id/deliveries", withUser, handler.getWebhookDeliveries)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", test.url, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.Equal(t, test.expectedResponseBody, w.Body.String())
+		})
+	}
+}
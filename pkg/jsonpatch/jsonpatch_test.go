@@ -0,0 +1,220 @@
+package jsonpatch
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "Replace Member",
+			doc:      `{"a":"b"}`,
+			patch:    `{"a":"c"}`,
+			expected: `{"a":"c"}`,
+		},
+		{
+			name:     "Add Member",
+			doc:      `{"a":"b"}`,
+			patch:    `{"b":"c"}`,
+			expected: `{"a":"b","b":"c"}`,
+		},
+		{
+			name:     "Remove Member",
+			doc:      `{"a":"b","b":"c"}`,
+			patch:    `{"a":null}`,
+			expected: `{"b":"c"}`,
+		},
+		{
+			name:     "Replace Array",
+			doc:      `{"a":["b"]}`,
+			patch:    `{"a":["c","d"]}`,
+			expected: `{"a":["c","d"]}`,
+		},
+		{
+			name:     "Merge Nested Object",
+			doc:      `{"a":{"b":"c","d":"e"}}`,
+			patch:    `{"a":{"d":null,"f":"g"}}`,
+			expected: `{"a":{"b":"c","f":"g"}}`,
+		},
+		{
+			name:     "Object Into Scalar",
+			doc:      `{"a":"b"}`,
+			patch:    `{"a":{"b":null,"c":"d"}}`,
+			expected: `{"a":{"c":"d"}}`,
+		},
+		{
+			name:     "Non Object Patch",
+			doc:      `{"a":"b"}`,
+			patch:    `["c"]`,
+			expected: `["c"]`,
+		},
+		{
+			name:     "Empty Patch",
+			doc:      `{"a":"b"}`,
+			patch:    `{}`,
+			expected: `{"a":"b"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(test.doc), []byte(test.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(result) != test.expected {
+				t.Errorf("got %s, expected %s", result, test.expected)
+			}
+		})
+	}
+
+	if _, err := MergePatch([]byte(`{}`), []byte(`{`)); err == nil {
+		t.Error("expected an error for an invalid patch")
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "Add Member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "Add Null Member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":null}]`,
+			expected: `{"baz":null,"foo":"bar"}`,
+		},
+		{
+			name:     "Insert Into Array",
+			doc:      `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			expected: `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:     "Append To Array",
+			doc:      `{"foo":["bar"]}`,
+			patch:    `[{"op":"add","path":"/foo/-","value":"qux"}]`,
+			expected: `{"foo":["bar","qux"]}`,
+		},
+		{
+			name:     "Remove Array Element",
+			doc:      `{"foo":["bar","qux","baz"]}`,
+			patch:    `[{"op":"remove","path":"/foo/1"}]`,
+			expected: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:     "Replace",
+			doc:      `{"baz":"qux","foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			expected: `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:     "Move",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			expected: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "Move Array Element",
+			doc:      `{"foo":["all","grass","cows","eat"]}`,
+			patch:    `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			expected: `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:     "Copy",
+			doc:      `{"foo":{"bar":1}}`,
+			patch:    `[{"op":"copy","from":"/foo","path":"/baz"},{"op":"replace","path":"/baz/bar","value":2}]`,
+			expected: `{"baz":{"bar":2},"foo":{"bar":1}}`,
+		},
+		{
+			name:     "Test Then Replace",
+			doc:      `{"baz":"qux","foo":[1,2]}`,
+			patch:    `[{"op":"test","path":"/foo","value":[1,2]},{"op":"replace","path":"/baz","value":"boo"}]`,
+			expected: `{"baz":"boo","foo":[1,2]}`,
+		},
+		{
+			name:     "Escaped Pointer",
+			doc:      `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":3}]`,
+			expected: `{"m~n":3}`,
+		},
+		{
+			name:     "Replace Whole Document",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"replace","path":"","value":{"baz":"qux"}}]`,
+			expected: `{"baz":"qux"}`,
+		},
+		{
+			name:     "Empty Patch",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[]`,
+			expected: `{"foo":"bar"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Apply([]byte(test.doc), []byte(test.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(result) != test.expected {
+				t.Errorf("got %s, expected %s", result, test.expected)
+			}
+		})
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		patch    string
+		expected string
+	}{
+		{name: "Not An Array", patch: `{"op":"add"}`, expected: "invalid json patch"},
+		{name: "Unknown Op", patch: `[{"op":"frobnicate","path":"/foo"}]`, expected: `unknown op "frobnicate"`},
+		{name: "Missing Path", patch: `[{"op":"remove"}]`, expected: "missing path"},
+		{name: "Missing Value", patch: `[{"op":"add","path":"/baz"}]`, expected: "add requires a value"},
+		{name: "Invalid Pointer", patch: `[{"op":"remove","path":"foo"}]`, expected: `invalid path "foo"`},
+		{name: "Remove Missing Member", patch: `[{"op":"remove","path":"/baz"}]`, expected: `path member "baz" not found`},
+		{name: "Replace Missing Member", patch: `[{"op":"replace","path":"/baz","value":1}]`, expected: `path member "baz" not found`},
+		{name: "Add To Missing Parent", patch: `[{"op":"add","path":"/baz/qux","value":1}]`, expected: `path member "baz" not found`},
+		{name: "Index Out Of Range", patch: `[{"op":"add","path":"/list/3","value":1}]`, expected: "array index 3 out of range"},
+		{name: "Leading Zero Index", patch: `[{"op":"remove","path":"/list/01"}]`, expected: `invalid array index "01"`},
+		{name: "Move Into Itself", patch: `[{"op":"move","from":"/list","path":"/list/0"}]`, expected: "cannot move /list into itself"},
+		{name: "Failed Op Index", patch: `[{"op":"replace","path":"/foo","value":1},{"op":"remove","path":"/baz"}]`, expected: "operation 1:"},
+	}
+
+	doc := []byte(`{"foo":"bar","list":[1,2]}`)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Apply(doc, []byte(test.patch))
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("got error %v, expected it to contain %q", err, test.expected)
+			}
+		})
+	}
+
+	_, err := Apply(doc, []byte(`[{"op":"test","path":"/foo","value":"baz"}]`))
+	if !errors.Is(err, ErrTestFailed) {
+		t.Errorf("got error %v, expected ErrTestFailed", err)
+	}
+	if string(doc) != `{"foo":"bar","list":[1,2]}` {
+		t.Errorf("document changed to %s", doc)
+	}
+}
@@ -0,0 +1,88 @@
+package migrate
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	for _, dir := range []string{"postgres", "sqlite"} {
+		t.Run(dir, func(t *testing.T) {
+			migrations, err := load(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for i, migration := range migrations {
+				if migration.Version != i+1 {
+					t.Errorf("expected migration %d to have version %d; got %d", i, i+1, migration.Version)
+				}
+			}
+		})
+	}
+
+	postgres, _ := load("postgres")
+	sqlite, _ := load("sqlite")
+	if len(postgres) != len(sqlite) {
+		t.Errorf("expected the same number of postgres and sqlite migrations; got %d and %d", len(postgres), len(sqlite))
+	}
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlx.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "todo.db")+"?_txlock=immediate")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer db.Close()
+
+	migrator, err := New(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	total := len(migrator.migrations)
+
+	countApplied := func() int {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		applied := 0
+		for _, status := range statuses {
+			if status.Applied {
+				applied++
+			}
+		}
+		return applied
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if applied := countApplied(); applied != total {
+		t.Errorf("expected %d applied migrations; got %d", total, applied)
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("expected repeated up to be a no-op; got %s", err)
+	}
+
+	if err := migrator.Down(ctx, total); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if applied := countApplied(); applied != 0 {
+		t.Errorf("expected no applied migrations; got %d", applied)
+	}
+	var tables int
+	if err := db.Get(&tables, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'todo_items'"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tables != 0 {
+		t.Errorf("expected todo_items to be dropped")
+	}
+
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("expected migrations to apply again after down; got %s", err)
+	}
+}
@@ -0,0 +1,8 @@
+-- IF NOT EXISTS lets databases created by the former scripts/init.sql adopt the migration history.
+CREATE TABLE IF NOT EXISTS todo_items (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    date TIMESTAMP NOT NULL,
+    is_done BOOLEAN NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo_items_user_id_idx;
+ALTER TABLE todo_items DROP COLUMN IF EXISTS user_id;
+DROP TABLE IF EXISTS users;
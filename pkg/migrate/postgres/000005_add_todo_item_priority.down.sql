@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS todo_items_user_id_priority_date_idx;
+ALTER TABLE todo_items DROP COLUMN priority;
@@ -0,0 +1,2 @@
+DROP INDEX todo_items_search_idx;
+ALTER TABLE todo_items DROP COLUMN search;
@@ -0,0 +1,10 @@
+-- Generated columns need PostgreSQL 12 or later.
+-- Title lexemes weigh more than description ones. Both configurations are indexed so that
+-- queries in either language match their stems.
+ALTER TABLE todo_items ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+CREATE INDEX todo_items_search_idx ON todo_items USING GIN (search);
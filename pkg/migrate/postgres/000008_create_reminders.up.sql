@@ -0,0 +1,15 @@
+-- remind_at is when a reminder fires: at, or before_minutes before the date of the item.
+-- The scheduler reserves a reminder until claimed_until while delivering it and sets sent_at
+-- once it has been delivered, so that it fires once across restarts and instances.
+CREATE TABLE reminders (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    item_id INT NOT NULL REFERENCES todo_items (id) ON DELETE CASCADE,
+    at TIMESTAMP,
+    before_minutes INT,
+    remind_at TIMESTAMP NOT NULL,
+    claimed_until TIMESTAMP,
+    sent_at TIMESTAMP
+);
+CREATE INDEX reminders_item_id_idx ON reminders (item_id);
+CREATE INDEX reminders_due_idx ON reminders (remind_at) WHERE sent_at IS NULL;
@@ -0,0 +1,30 @@
+-- events is a comma-separated list of subscribed item events; empty subscribes to all of them.
+CREATE TABLE webhooks (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    events TEXT NOT NULL DEFAULT '',
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX webhooks_user_id_idx ON webhooks (user_id);
+
+-- A delivery is queued for every subscribed webhook when an event happens and is kept as the
+-- delivery log. A pending delivery is attempted at next_attempt_at; the dispatcher reserves it
+-- until claimed_until while sending.
+CREATE TABLE webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    claimed_until TIMESTAMP,
+    last_attempt_at TIMESTAMP,
+    response_status INT,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,10 @@
+-- item_events buffers the latest item events of every user for the event stream to resume from;
+-- older events are trimmed as new ones are appended.
+CREATE TABLE item_events (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE INDEX item_events_user_id_idx ON item_events (user_id, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS todo_items_parent_id_idx;
+ALTER TABLE todo_items DROP COLUMN parent_id;
@@ -0,0 +1,4 @@
+-- parent_id makes an item a subtask of another item of the same user; deleting an item deletes
+-- its subtasks.
+ALTER TABLE todo_items ADD COLUMN parent_id INT REFERENCES todo_items (id) ON DELETE CASCADE;
+CREATE INDEX todo_items_parent_id_idx ON todo_items (parent_id);
@@ -0,0 +1,8 @@
+-- A row makes blocker_id a prerequisite of item_id: item_id cannot be marked done while
+-- blocker_id is open.
+CREATE TABLE todo_item_blockers (
+    item_id INT NOT NULL REFERENCES todo_items (id) ON DELETE CASCADE,
+    blocker_id INT NOT NULL REFERENCES todo_items (id) ON DELETE CASCADE,
+    PRIMARY KEY (item_id, blocker_id)
+);
+CREATE INDEX todo_item_blockers_blocker_id_idx ON todo_item_blockers (blocker_id);
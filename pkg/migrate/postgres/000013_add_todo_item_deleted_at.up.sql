@@ -0,0 +1,3 @@
+-- Deleted items stay in the trash with deleted_at set until they are restored or purged.
+ALTER TABLE todo_items ADD COLUMN deleted_at TIMESTAMP;
+CREATE INDEX todo_items_deleted_at_idx ON todo_items (deleted_at);
@@ -0,0 +1,3 @@
+-- next_id links an occurrence of a recurring item to the occurrence created when it was marked
+-- done, so that marking it done again does not create another one.
+ALTER TABLE todo_items ADD COLUMN next_id INT REFERENCES todo_items (id) ON DELETE SET NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL
+);
+
+-- Items created before accounts existed keep a NULL owner and are not visible to any user. No
+-- account exists yet to take them over: once their owner has signed up, assign them with
+-- UPDATE todo_items SET user_id = <id> WHERE user_id IS NULL.
+ALTER TABLE todo_items ADD COLUMN user_id INTEGER REFERENCES users (id) ON DELETE CASCADE;
+CREATE INDEX todo_items_user_id_idx ON todo_items (user_id);
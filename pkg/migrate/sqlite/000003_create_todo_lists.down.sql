@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo_items_list_id_idx;
+ALTER TABLE todo_items DROP COLUMN list_id;
+DROP TABLE IF EXISTS todo_lists;
@@ -0,0 +1,11 @@
+CREATE TABLE todo_lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT
+);
+CREATE INDEX todo_lists_user_id_idx ON todo_lists (user_id);
+
+-- Deleting a list keeps its items by default; the repository removes them explicitly when asked to cascade.
+ALTER TABLE todo_items ADD COLUMN list_id INTEGER REFERENCES todo_lists (id) ON DELETE SET NULL;
+CREATE INDEX todo_items_list_id_idx ON todo_items (list_id);
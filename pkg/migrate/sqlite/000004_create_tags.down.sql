@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo_item_tags;
+DROP TABLE IF EXISTS tags;
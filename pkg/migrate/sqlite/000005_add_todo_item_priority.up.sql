@@ -0,0 +1,3 @@
+-- 1 is the most urgent priority and 4 the lowest; existing items get the lowest one.
+ALTER TABLE todo_items ADD COLUMN priority SMALLINT NOT NULL DEFAULT 4;
+CREATE INDEX todo_items_user_id_priority_date_idx ON todo_items (user_id, priority, date);
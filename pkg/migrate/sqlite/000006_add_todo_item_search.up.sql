@@ -0,0 +1,2 @@
+-- SQLite has no tsvector: the repository matches every term of a search query with LIKE
+-- against the lowercased title and description, so this migration changes nothing.
@@ -0,0 +1,2 @@
+ALTER TABLE todo_items DROP COLUMN timezone;
+ALTER TABLE todo_items DROP COLUMN recurrence;
@@ -0,0 +1,4 @@
+-- recurrence is an RFC 5545 RRULE expanded in timezone, an IANA name; empty values mean a
+-- one-off item and UTC.
+ALTER TABLE todo_items ADD COLUMN recurrence TEXT NOT NULL DEFAULT '';
+ALTER TABLE todo_items ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS todo_items_deleted_at_idx;
+ALTER TABLE todo_items DROP COLUMN deleted_at;
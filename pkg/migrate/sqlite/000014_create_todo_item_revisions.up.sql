@@ -0,0 +1,10 @@
+-- A row records one update of item_id: changes is a JSON array of the changed fields with their
+-- old and new values. Revisions are numbered from 1 for every item.
+CREATE TABLE todo_item_revisions (
+    item_id INTEGER NOT NULL REFERENCES todo_items (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    actor_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    changes TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (item_id, revision)
+);
@@ -0,0 +1,2 @@
+-- version grows with every change of the item and backs the ETag of its API representation.
+ALTER TABLE todo_items ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,81 @@
+// Package notify delivers due reminders to the outside world.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// Notifier delivers one reminder. A returned error makes the scheduler retry the delivery later.
+type Notifier interface {
+	Notify(ctx context.Context, reminder todoListSber.DueReminder) error
+}
+
+// LogNotifier writes reminders to a structured logger.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+func (n *LogNotifier) Notify(ctx context.Context, reminder todoListSber.DueReminder) error {
+	n.logger.InfoContext(ctx, "reminder",
+		"reminder_id", reminder.Id, "user_id", reminder.UserId, "item_id", reminder.ItemId,
+		"title", reminder.Item.Title, "date", reminder.Item.Date, "remind_at", reminder.RemindAt)
+	return nil
+}
+
+// webhookPayload is the JSON body posted by WebhookNotifier.
+type webhookPayload struct {
+	ReminderId int                   `json:"reminder_id"`
+	UserId     int                   `json:"user_id"`
+	RemindAt   time.Time             `json:"remind_at"`
+	Item       todoListSber.TodoItem `json:"item"`
+}
+
+// WebhookNotifier posts reminders as JSON to a URL. Every request carries the reminder id in the
+// Idempotency-Key header, so that receivers can drop the rare repeated delivery of a reminder whose
+// delivery succeeded but could not be recorded.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: timeout}}
+}
+func (n *WebhookNotifier) Notify(ctx context.Context, reminder todoListSber.DueReminder) error {
+	body, err := json.Marshal(webhookPayload{
+		ReminderId: reminder.Id,
+		UserId:     reminder.UserId,
+		RemindAt:   reminder.RemindAt,
+		Item:       reminder.Item,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "reminder-"+strconv.Itoa(reminder.Id))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
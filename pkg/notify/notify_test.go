@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	var got struct {
+		ReminderId int                   `json:"reminder_id"`
+		UserId     int                   `json:"user_id"`
+		Item       todoListSber.TodoItem `json:"item"`
+	}
+	var key string
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	reminder := todoListSber.DueReminder{
+		Reminder: todoListSber.Reminder{Id: 7, ItemId: 3, RemindAt: time.Now()},
+		UserId:   2,
+		Item:     todoListSber.TodoItem{Id: 3, Title: "Call mom"},
+	}
+	if err := notifier.Notify(context.Background(), reminder); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.ReminderId != 7 || got.UserId != 2 || got.Item.Title != "Call mom" {
+		t.Errorf("unexpected payload %+v", got)
+	}
+	if key != "reminder-7" {
+		t.Errorf("expected Idempotency-Key reminder-7; got %q", key)
+	}
+
+	status = http.StatusBadGateway
+	if err := notifier.Notify(context.Background(), reminder); err == nil {
+		t.Errorf("expected an error for status %d", status)
+	}
+}
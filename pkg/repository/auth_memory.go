@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	todoListSber "todo-list-sber"
+)
+
+type AuthMemory struct {
+	mu     sync.RWMutex
+	users  map[string]todoListSber.User
+	nextId int
+}
+
+func NewAuthMemory() *AuthMemory {
+	return &AuthMemory{
+		users:  make(map[string]todoListSber.User),
+		nextId: 1,
+	}
+}
+func (r *AuthMemory) CreateUser(ctx context.Context, user todoListSber.User) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.Username]; ok {
+		return 0, todoListSber.ErrUsernameTaken
+	}
+	user.Id = r.nextId
+	user.Password = ""
+	r.users[user.Username] = user
+	r.nextId++
+	return user.Id, nil
+}
+func (r *AuthMemory) GetUser(ctx context.Context, username string) (todoListSber.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[username]
+	if !ok {
+		return todoListSber.User{}, todoListSber.ErrUserNotFound
+	}
+	return user, nil
+}
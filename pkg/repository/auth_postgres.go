@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	todoListSber "todo-list-sber"
+)
+
+// uniqueViolation is the Postgres SQLSTATE for a unique constraint violation.
+const uniqueViolation = "23505"
+
+type AuthPostgres struct {
+	db dbConn
+}
+
+func NewAuthPostgres(db *sqlx.DB) *AuthPostgres {
+	return &AuthPostgres{db: sqlDB{db}}
+}
+func (r *AuthPostgres) CreateUser(ctx context.Context, user todoListSber.User) (int, error) {
+	var id int
+	query := "INSERT INTO users (username, password_hash) VALUES ($1, $2) RETURNING id"
+	err := r.db.QueryRowxContext(ctx, query, user.Username, user.PasswordHash).Scan(&id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return 0, todoListSber.ErrUsernameTaken
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+func (r *AuthPostgres) GetUser(ctx context.Context, username string) (todoListSber.User, error) {
+	var user todoListSber.User
+	query := "SELECT id, username, password_hash FROM users WHERE username = $1"
+	err := r.db.GetContext(ctx, &user, query, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, todoListSber.ErrUserNotFound
+	}
+	return user, err
+}
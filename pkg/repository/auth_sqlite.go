@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	todoListSber "todo-list-sber"
+)
+
+type AuthSQLite struct {
+	db dbConn
+}
+
+func NewAuthSQLite(db *sqlx.DB) *AuthSQLite {
+	return &AuthSQLite{db: sqlDB{db}}
+}
+func (r *AuthSQLite) CreateUser(ctx context.Context, user todoListSber.User) (int, error) {
+	query := "INSERT INTO users (username, password_hash) VALUES (?, ?)"
+	res, err := r.db.ExecContext(ctx, query, user.Username, user.PasswordHash)
+	if isUniqueViolation(err) {
+		return 0, todoListSber.ErrUsernameTaken
+	}
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+func (r *AuthSQLite) GetUser(ctx context.Context, username string) (todoListSber.User, error) {
+	var user todoListSber.User
+	query := "SELECT id, username, password_hash FROM users WHERE username = ?"
+	err := r.db.GetContext(ctx, &user, query, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, todoListSber.ErrUserNotFound
+	}
+	return user, err
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	todoListSber "todo-list-sber"
+)
+
+func TestAuthorization(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := repos.Authorization
+
+			id, err := repo.CreateUser(ctx, todoListSber.User{Username: "alice", PasswordHash: "hash"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			user, err := repo.GetUser(ctx, "alice")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if user.Id != id || user.PasswordHash != "hash" {
+				t.Errorf("expected user %d with stored hash; got %+v", id, user)
+			}
+
+			var conflict *todoListSber.ConflictError
+			if _, err := repo.CreateUser(ctx, todoListSber.User{Username: "alice", PasswordHash: "other"}); !errors.As(err, &conflict) {
+				t.Errorf("expected ConflictError for a duplicate username; got %v", err)
+			}
+			var notFound *todoListSber.NotFoundError
+			if _, err := repo.GetUser(ctx, "bob"); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for an unknown username; got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	todoListSber "todo-list-sber"
+)
+
+// DependencyMemory serves blockers out of the TodoItemMemory store, which owns them together with the items.
+type DependencyMemory struct {
+	items *TodoItemMemory
+}
+
+func NewDependencyMemory(items *TodoItemMemory) *DependencyMemory {
+	return &DependencyMemory{items: items}
+}
+func (r *DependencyMemory) GetBlockers(ctx context.Context, userId, itemId int) ([]todoListSber.TodoItem, error) {
+	r.items.mu.RLock()
+	defer r.items.mu.RUnlock()
+
+	if err := r.checkItems(userId, itemId); err != nil {
+		return nil, err
+	}
+	var blockers []todoListSber.TodoItem
+	for blockerId := range r.items.blockers[itemId] {
+		if r.items.items[blockerId].DeletedAt != nil {
+			continue
+		}
+		blockers = append(blockers, r.items.withTags(r.items.items[blockerId]))
+	}
+	sortItems(blockers, todoListSber.TodoItemQuery{Sort: todoListSber.SortByDate})
+	return blockers, nil
+}
+func (r *DependencyMemory) Add(ctx context.Context, userId, itemId, blockerId int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if err := r.checkItems(userId, itemId, blockerId); err != nil {
+		return err
+	}
+	if r.prerequisite(blockerId, itemId, make(map[int]bool)) {
+		return todoListSber.ErrDependencyCycle
+	}
+	if r.items.blockers[itemId] == nil {
+		setEntry(r.items.undo, r.items.blockers, itemId, make(map[int]bool))
+	}
+	setEntry(r.items.undo, r.items.blockers[itemId], blockerId, true)
+	return nil
+}
+func (r *DependencyMemory) Remove(ctx context.Context, userId, itemId, blockerId int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if err := r.checkItems(userId, itemId, blockerId); err != nil {
+		return err
+	}
+	if !r.items.blockers[itemId][blockerId] {
+		return todoListSber.ErrBlockerNotFound(itemId, blockerId)
+	}
+	deleteEntry(r.items.undo, r.items.blockers[itemId], blockerId)
+	return nil
+}
+
+// prerequisite reports whether target is id or blocks it at any depth.
+func (r *DependencyMemory) prerequisite(id, target int, seen map[int]bool) bool {
+	if id == target {
+		return true
+	}
+	seen[id] = true
+	for blockerId := range r.items.blockers[id] {
+		if !seen[blockerId] && r.prerequisite(blockerId, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DependencyMemory) checkItems(userId int, ids ...int) error {
+	for _, id := range ids {
+		if !r.items.owned(userId, id) {
+			return todoListSber.ErrTodoItemNotFound(id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	todoListSber "todo-list-sber"
+)
+
+// prerequisitesQuery collects an item and the items blocking it at any depth. Items in the trash
+// are followed too, so that restoring them cannot close a cycle.
+const prerequisitesQuery = `WITH RECURSIVE prerequisites (id) AS (
+		SELECT id FROM todo_items WHERE id = ?
+		UNION
+		SELECT b.blocker_id FROM todo_item_blockers b JOIN prerequisites p ON b.item_id = p.id
+	)
+	SELECT COUNT(*) FROM prerequisites WHERE id = ?`
+
+// DependencySQL implements Dependency for both Postgres and SQLite; its queries are rebound for the driver.
+type DependencySQL struct {
+	db      dbConn
+	dialect itemQueryDialect
+}
+
+func NewDependencySQL(db *sqlx.DB) *DependencySQL {
+	return &DependencySQL{db: sqlDB{db}, dialect: dialectOf(db)}
+}
+func (r *DependencySQL) GetBlockers(ctx context.Context, userId, itemId int) ([]todoListSber.TodoItem, error) {
+	if err := checkItem(ctx, r.db, userId, itemId); err != nil {
+		return nil, err
+	}
+	var items []todoListSber.TodoItem
+	query := "SELECT " + todoItemColumns + " FROM todo_items WHERE id IN (SELECT blocker_id FROM todo_item_blockers WHERE item_id = ?) AND deleted_at IS NULL ORDER BY date, id"
+	if err := r.db.SelectContext(ctx, &items, r.db.Rebind(query), itemId); err != nil {
+		return nil, err
+	}
+	if err := loadTags(ctx, r.db, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+func (r *DependencySQL) Add(ctx context.Context, userId, itemId, blockerId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := r.dialect.lockUser(ctx, tx, userId, todoListSber.ErrTodoItemNotFound(itemId)); err != nil {
+		return err
+	}
+	if err := checkItems(ctx, tx, userId, itemId, blockerId); err != nil {
+		return err
+	}
+	var cycle int
+	if err := tx.GetContext(ctx, &cycle, tx.Rebind(prerequisitesQuery), blockerId, itemId); err != nil {
+		return err
+	}
+	if cycle > 0 {
+		return todoListSber.ErrDependencyCycle
+	}
+	query := "INSERT INTO todo_item_blockers (item_id, blocker_id) VALUES (?, ?) ON CONFLICT DO NOTHING"
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), itemId, blockerId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *DependencySQL) Remove(ctx context.Context, userId, itemId, blockerId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkItems(ctx, tx, userId, itemId, blockerId); err != nil {
+		return err
+	}
+	query := "DELETE FROM todo_item_blockers WHERE item_id = ? AND blocker_id = ?"
+	res, err := tx.ExecContext(ctx, tx.Rebind(query), itemId, blockerId)
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(res, todoListSber.ErrBlockerNotFound(itemId, blockerId)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// checkItems makes sure all the items belong to the user.
+func checkItems(ctx context.Context, tx dbTx, userId int, ids ...int) error {
+	for _, id := range ids {
+		if err := checkItem(ctx, tx, userId, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
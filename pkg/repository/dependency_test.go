@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestDependency(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			alice := createTestUser(t, repos, "alice")
+			bob := createTestUser(t, repos, "bob")
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			create := func(userId int, title string, days int) int {
+				id, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: title, Date: date.AddDate(0, 0, days)})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return id
+			}
+			release := create(alice, "Release", 3)
+			tests := create(alice, "Tests", 2)
+			build := create(alice, "Build", 1)
+			foreign := create(bob, "Foreign", 0)
+
+			for _, dependency := range [][2]int{{release, tests}, {release, build}, {tests, build}, {tests, build}} {
+				if err := repos.Dependency.Add(ctx, alice, dependency[0], dependency[1]); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			blockers, err := repos.Dependency.GetBlockers(ctx, alice, release)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(blockers) != 2 || blockers[0].Id != build || blockers[1].Id != tests {
+				t.Errorf("expected build and tests to block the release; got %+v", blockers)
+			}
+
+			if err := repos.Dependency.Add(ctx, alice, build, release); !errors.Is(err, todoListSber.ErrDependencyCycle) {
+				t.Errorf("expected ErrDependencyCycle; got %v", err)
+			}
+			if err := repos.Dependency.Add(ctx, alice, build, build); !errors.Is(err, todoListSber.ErrDependencyCycle) {
+				t.Errorf("expected ErrDependencyCycle for a self dependency; got %v", err)
+			}
+			var notFound *todoListSber.NotFoundError
+			if err := repos.Dependency.Add(ctx, alice, release, foreign); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for an item of another user; got %v", err)
+			}
+			if _, err := repos.Dependency.GetBlockers(ctx, bob, release); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from GetBlockers; got %v", err)
+			}
+
+			ready := func() []string {
+				page, err := repos.TodoItem.GetAll(ctx, alice, todoListSber.TodoItemQuery{Ready: true, Sort: todoListSber.SortByDate})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				var titles []string
+				for _, item := range page.Items {
+					titles = append(titles, item.Title)
+				}
+				return titles
+			}
+			if expected := []string{"Build"}; !reflect.DeepEqual(ready(), expected) {
+				t.Errorf("expected ready %v; got %v", expected, ready())
+			}
+			isDone := true
+			if err := repos.TodoItem.Update(ctx, alice, build, todoListSber.UpdateItemInput{IsDone: &isDone}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := []string{"Tests"}; !reflect.DeepEqual(ready(), expected) {
+				t.Errorf("expected ready %v; got %v", expected, ready())
+			}
+
+			if err := repos.Dependency.Remove(ctx, alice, release, tests); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repos.Dependency.Remove(ctx, alice, release, tests); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for a blocker already removed; got %v", err)
+			}
+			if err := repos.TodoItem.Delete(ctx, alice, build); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := []string{"Tests", "Release"}; !reflect.DeepEqual(ready(), expected) {
+				t.Errorf("expected ready %v; got %v", expected, ready())
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	todoListSber "todo-list-sber"
+)
+
+// EventLogMemory keeps the event buffers in process memory.
+type EventLogMemory struct {
+	mu     sync.RWMutex
+	events map[int][]todoListSber.StoredEvent
+	nextId int
+}
+
+func NewEventLogMemory() *EventLogMemory {
+	return &EventLogMemory{
+		events: make(map[int][]todoListSber.StoredEvent),
+		nextId: 1,
+	}
+}
+func (r *EventLogMemory) Append(ctx context.Context, userId int, event todoListSber.StoredEvent, keep int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.Id = r.nextId
+	event.Payload = json.RawMessage(slices.Clone(event.Payload))
+	events := append(r.events[userId], event)
+	if len(events) > keep {
+		events = slices.Clone(events[len(events)-keep:])
+	}
+	r.events[userId] = events
+	r.nextId++
+	return event.Id, nil
+}
+func (r *EventLogMemory) After(ctx context.Context, userId, afterId, limit int) ([]todoListSber.StoredEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []todoListSber.StoredEvent
+	for _, event := range r.events[userId] {
+		if event.Id > afterId && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+func (r *EventLogMemory) Latest(ctx context.Context, userId int) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := r.events[userId]
+	if len(events) == 0 {
+		return 0, nil
+	}
+	return events[len(events)-1].Id, nil
+}
+func (r *EventLogMemory) Has(ctx context.Context, userId, id int) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.ContainsFunc(r.events[userId], func(event todoListSber.StoredEvent) bool {
+		return event.Id == id
+	}), nil
+}
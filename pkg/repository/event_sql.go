@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	todoListSber "todo-list-sber"
+)
+
+type storedEventRow struct {
+	todoListSber.StoredEvent
+	Payload string `db:"payload"`
+}
+
+// EventLogSQL implements EventLog for both Postgres and SQLite. Times are written in UTC, as in ReminderSQL.
+type EventLogSQL struct {
+	db dbConn
+}
+
+func NewEventLogSQL(db *sqlx.DB) *EventLogSQL {
+	return &EventLogSQL{db: sqlDB{db}}
+}
+func (r *EventLogSQL) Append(ctx context.Context, userId int, event todoListSber.StoredEvent, keep int) (int, error) {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// The id is taken from a sequence when the event is inserted, but a stream resumes after the
+	// highest id it has seen, so the events of a user must commit in the order of their ids. The
+	// lock on the row of the user keeps the next event of the user from being inserted until this
+	// one has committed.
+	if err := dialectOf(tx).lockUser(ctx, tx, userId, todoListSber.ErrUserNotFound); err != nil {
+		return 0, err
+	}
+	var id int
+	insertQuery := tx.Rebind("INSERT INTO item_events (user_id, event, payload, created_at) VALUES (?, ?, ?, ?) RETURNING id")
+	if err := tx.QueryRowxContext(ctx, insertQuery, userId, event.Event, string(event.Payload), event.CreatedAt.UTC()).Scan(&id); err != nil {
+		return 0, err
+	}
+	// The subquery finds the oldest event to keep; it is NULL, and nothing is trimmed, while the
+	// buffer is not full yet.
+	trimQuery := tx.Rebind(`DELETE FROM item_events WHERE user_id = ? AND id <
+		(SELECT id FROM item_events WHERE user_id = ? ORDER BY id DESC LIMIT 1 OFFSET ?)`)
+	if _, err := tx.ExecContext(ctx, trimQuery, userId, userId, keep-1); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+func (r *EventLogSQL) After(ctx context.Context, userId, afterId, limit int) ([]todoListSber.StoredEvent, error) {
+	var rows []storedEventRow
+	query := r.db.Rebind("SELECT id, event, payload, created_at FROM item_events WHERE user_id = ? AND id > ? ORDER BY id LIMIT ?")
+	if err := r.db.SelectContext(ctx, &rows, query, userId, afterId, limit); err != nil {
+		return nil, err
+	}
+	var events []todoListSber.StoredEvent
+	for _, row := range rows {
+		event := row.StoredEvent
+		event.Payload = json.RawMessage(row.Payload)
+		events = append(events, event)
+	}
+	return events, nil
+}
+func (r *EventLogSQL) Latest(ctx context.Context, userId int) (int, error) {
+	var id int
+	query := r.db.Rebind("SELECT COALESCE(MAX(id), 0) FROM item_events WHERE user_id = ?")
+	err := r.db.GetContext(ctx, &id, query, userId)
+	return id, err
+}
+func (r *EventLogSQL) Has(ctx context.Context, userId, id int) (bool, error) {
+	var n int
+	query := r.db.Rebind("SELECT COUNT(*) FROM item_events WHERE user_id = ? AND id = ?")
+	err := r.db.GetContext(ctx, &n, query, userId, id)
+	return n > 0, err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestEventLog(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			aliceId := createTestUser(t, repos, "alice")
+			bobId := createTestUser(t, repos, "bob")
+			now := time.Now().UTC().Truncate(time.Second)
+
+			if latest, err := repos.EventLog.Latest(ctx, aliceId); err != nil || latest != 0 {
+				t.Fatalf("expected an empty buffer; got %d, %v", latest, err)
+			}
+			var ids []int
+			for _, event := range []string{todoListSber.EventItemCreated, todoListSber.EventItemUpdated, todoListSber.EventItemDeleted} {
+				id, err := repos.EventLog.Append(ctx, aliceId, todoListSber.StoredEvent{Event: event, Payload: []byte(`{"event":"` + event + `"}`), CreatedAt: now}, 2)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				ids = append(ids, id)
+			}
+			if _, err := repos.EventLog.Append(ctx, bobId, todoListSber.StoredEvent{Event: todoListSber.EventItemCreated, Payload: []byte(`{}`), CreatedAt: now}, 2); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Only the newest two events of alice are kept.
+			events, err := repos.EventLog.After(ctx, aliceId, 0, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(events) != 2 || events[0].Id != ids[1] || events[1].Event != todoListSber.EventItemDeleted || string(events[1].Payload) != `{"event":"item.deleted"}` {
+				t.Fatalf("unexpected events %+v", events)
+			}
+			if events, err := repos.EventLog.After(ctx, aliceId, ids[1], 10); err != nil || len(events) != 1 || events[0].Id != ids[2] {
+				t.Errorf("expected the event after %d; got %+v, %v", ids[1], events, err)
+			}
+			if has, err := repos.EventLog.Has(ctx, aliceId, ids[0]); err != nil || has {
+				t.Errorf("expected event %d to be trimmed; got %v, %v", ids[0], has, err)
+			}
+			if has, err := repos.EventLog.Has(ctx, bobId, ids[2]); err != nil || has {
+				t.Errorf("expected the events of alice to be hidden from bob; got %v, %v", has, err)
+			}
+			if latest, err := repos.EventLog.Latest(ctx, aliceId); err != nil || latest != ids[2] {
+				t.Errorf("expected latest %d; got %d, %v", ids[2], latest, err)
+			}
+		})
+	}
+}
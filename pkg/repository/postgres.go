@@ -5,17 +5,38 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+	DriverMemory   = "memory"
+)
+
 type Config struct {
+	Driver   string
 	Host     string
 	Port     string
 	Username string
 	Password string
 	DBName   string
 	SSLMode  string
+	// Path is the database file used by the SQLite driver.
+	Path string
+}
+
+// NewDB opens a database connection for the driver named in cfg.
+func NewDB(cfg Config) (*sqlx.DB, error) {
+	switch cfg.Driver {
+	case DriverPostgres, "":
+		return NewPostgresDB(cfg)
+	case DriverSQLite:
+		return NewSQLiteDB(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
+	}
 }
 
 func NewPostgresDB(cfg Config) (*sqlx.DB, error) {
-	db, err := sqlx.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
+	db, err := sqlx.Open(DriverPostgres, fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.DBName, cfg.Password, cfg.SSLMode))
 	if err != nil {
 		return nil, err
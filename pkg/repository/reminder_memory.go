@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// ReminderMemory serves reminders out of the TodoItemMemory store, which owns them together with the items.
+type ReminderMemory struct {
+	items *TodoItemMemory
+}
+
+func NewReminderMemory(items *TodoItemMemory) *ReminderMemory {
+	return &ReminderMemory{items: items}
+}
+func (r *ReminderMemory) Create(ctx context.Context, userId, itemId int, reminder todoListSber.Reminder) (int, error) {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if !r.items.owned(userId, itemId) {
+		return 0, todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	reminder.Id = r.items.nextReminderId
+	reminder.ItemId = itemId
+	reminder.SentAt = nil
+	setEntry(r.items.undo, r.items.reminders, reminder.Id, memoryReminder{Reminder: reminder, userId: userId})
+	r.items.nextReminderId++
+	return reminder.Id, nil
+}
+func (r *ReminderMemory) GetByItem(ctx context.Context, userId, itemId int) ([]todoListSber.Reminder, error) {
+	r.items.mu.RLock()
+	defer r.items.mu.RUnlock()
+
+	if !r.items.owned(userId, itemId) {
+		return nil, todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	var reminders []todoListSber.Reminder
+	for _, reminder := range r.items.reminders {
+		if reminder.ItemId == itemId {
+			reminders = append(reminders, reminder.Reminder)
+		}
+	}
+	sortReminders(reminders)
+	return reminders, nil
+}
+func (r *ReminderMemory) Delete(ctx context.Context, userId, itemId, id int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if !r.items.owned(userId, itemId) {
+		return todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	if reminder, ok := r.items.reminders[id]; !ok || reminder.ItemId != itemId {
+		return todoListSber.ErrReminderNotFound(id)
+	}
+	deleteEntry(r.items.undo, r.items.reminders, id)
+	return nil
+}
+func (r *ReminderMemory) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.DueReminder, error) {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	var candidates []todoListSber.Reminder
+	for _, reminder := range r.items.reminders {
+		if reminder.SentAt != nil || reminder.RemindAt.After(now) || reminder.claimedUntil.After(now) ||
+			r.items.items[reminder.ItemId].IsDone || r.items.items[reminder.ItemId].DeletedAt != nil {
+			continue
+		}
+		candidates = append(candidates, reminder.Reminder)
+	}
+	sortReminders(candidates)
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	due := make([]todoListSber.DueReminder, len(candidates))
+	for i, candidate := range candidates {
+		reminder := r.items.reminders[candidate.Id]
+		reminder.claimedUntil = now.Add(lease)
+		setEntry(r.items.undo, r.items.reminders, candidate.Id, reminder)
+		due[i] = todoListSber.DueReminder{
+			Reminder: candidate,
+			UserId:   reminder.userId,
+			Item:     r.items.withTags(r.items.items[candidate.ItemId]),
+		}
+	}
+	return due, nil
+}
+func (r *ReminderMemory) MarkSent(ctx context.Context, id int, at time.Time) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	reminder, ok := r.items.reminders[id]
+	if !ok {
+		return todoListSber.ErrReminderNotFound(id)
+	}
+	reminder.SentAt = &at
+	reminder.claimedUntil = time.Time{}
+	setEntry(r.items.undo, r.items.reminders, id, reminder)
+	return nil
+}
+
+// rescheduleReminders mirrors the SQL helper of the same name; the caller holds the lock.
+func (r *TodoItemMemory) rescheduleReminders(itemId int, date, now time.Time) {
+	for id, reminder := range r.reminders {
+		if reminder.ItemId != itemId || reminder.BeforeMinutes == nil {
+			continue
+		}
+		reminder.RemindAt = reminder.Schedule(date)
+		if reminder.RemindAt.After(now) {
+			reminder.SentAt = nil
+			reminder.claimedUntil = time.Time{}
+		}
+		setEntry(r.undo, r.reminders, id, reminder)
+	}
+}
+
+func sortReminders(reminders []todoListSber.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].RemindAt.Equal(reminders[j].RemindAt) {
+			return reminders[i].RemindAt.Before(reminders[j].RemindAt)
+		}
+		return reminders[i].Id < reminders[j].Id
+	})
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+const reminderColumns = "id, item_id, at, before_minutes, remind_at, sent_at"
+
+// ReminderSQL implements Reminder for both Postgres and SQLite; its queries are rebound for the driver.
+// Times are written in UTC: Postgres TIMESTAMP drops the offset and SQLite compares the text the
+// driver stores, so mixed offsets would break the due scan of Claim.
+type ReminderSQL struct {
+	db dbConn
+}
+
+func NewReminderSQL(db *sqlx.DB) *ReminderSQL {
+	return &ReminderSQL{db: sqlDB{db}}
+}
+func (r *ReminderSQL) Create(ctx context.Context, userId, itemId int, reminder todoListSber.Reminder) (int, error) {
+	if err := checkItem(ctx, r.db, userId, itemId); err != nil {
+		return 0, err
+	}
+	var id int
+	query := r.db.Rebind("INSERT INTO reminders (user_id, item_id, at, before_minutes, remind_at) VALUES (?, ?, ?, ?, ?) RETURNING id")
+	err := r.db.QueryRowxContext(ctx, query, userId, itemId, utcPtr(reminder.At), reminder.BeforeMinutes, reminder.RemindAt.UTC()).Scan(&id)
+	return id, err
+}
+func (r *ReminderSQL) GetByItem(ctx context.Context, userId, itemId int) ([]todoListSber.Reminder, error) {
+	if err := checkItem(ctx, r.db, userId, itemId); err != nil {
+		return nil, err
+	}
+	var reminders []todoListSber.Reminder
+	query := r.db.Rebind("SELECT " + reminderColumns + " FROM reminders WHERE item_id = ? ORDER BY remind_at, id")
+	err := r.db.SelectContext(ctx, &reminders, query, itemId)
+	return reminders, err
+}
+func (r *ReminderSQL) Delete(ctx context.Context, userId, itemId, id int) error {
+	if err := checkItem(ctx, r.db, userId, itemId); err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx, r.db.Rebind("DELETE FROM reminders WHERE id = ? AND item_id = ?"), id, itemId)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrReminderNotFound(id))
+}
+func (r *ReminderSQL) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.DueReminder, error) {
+	now = now.UTC()
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var candidates []struct {
+		todoListSber.Reminder
+		UserId int `db:"user_id"`
+	}
+	selectQuery := tx.Rebind(`SELECT r.id, r.item_id, r.at, r.before_minutes, r.remind_at, r.sent_at, r.user_id
+		FROM reminders r JOIN todo_items i ON i.id = r.item_id
+		WHERE r.sent_at IS NULL AND r.remind_at <= ? AND (r.claimed_until IS NULL OR r.claimed_until <= ?) AND NOT i.is_done AND i.deleted_at IS NULL
+		ORDER BY r.remind_at, r.id LIMIT ?`)
+	if err := tx.SelectContext(ctx, &candidates, selectQuery, now, now, limit); err != nil {
+		return nil, err
+	}
+
+	// The conditional update makes the claim safe against other instances scanning concurrently.
+	claimQuery := tx.Rebind("UPDATE reminders SET claimed_until = ? WHERE id = ? AND sent_at IS NULL AND (claimed_until IS NULL OR claimed_until <= ?)")
+	var due []todoListSber.DueReminder
+	for _, candidate := range candidates {
+		res, err := tx.ExecContext(ctx, claimQuery, now.Add(lease), candidate.Id, now)
+		if err != nil {
+			return nil, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		due = append(due, todoListSber.DueReminder{Reminder: candidate.Reminder, UserId: candidate.UserId})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for i := range due {
+		item := &due[i].Item
+		err := r.db.GetContext(ctx, item, r.db.Rebind("SELECT "+todoItemColumns+" FROM todo_items WHERE id = ?"), due[i].ItemId)
+		if err != nil {
+			return nil, err
+		}
+		items := []todoListSber.TodoItem{*item}
+		if err := loadTags(ctx, r.db, items); err != nil {
+			return nil, err
+		}
+		*item = items[0]
+	}
+	return due, nil
+}
+func (r *ReminderSQL) MarkSent(ctx context.Context, id int, at time.Time) error {
+	res, err := r.db.ExecContext(ctx, r.db.Rebind("UPDATE reminders SET sent_at = ?, claimed_until = NULL WHERE id = ?"), at.UTC(), id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrReminderNotFound(id))
+}
+
+// checkItem reports ErrTodoItemNotFound unless itemId is an item of userId.
+func checkItem(ctx context.Context, q sqlx.ExtContext, userId, itemId int) error {
+	var id int
+	err := sqlx.GetContext(ctx, q, &id, q.Rebind("SELECT id FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL"), itemId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	return err
+}
+
+// rescheduleReminders moves the offset reminders of itemId to an item date of date. Reminders that
+// now lie in the future are re-armed, so moving an item later reminds about it again.
+func rescheduleReminders(ctx context.Context, tx dbTx, itemId int, date, now time.Time) error {
+	var reminders []todoListSber.Reminder
+	selectQuery := tx.Rebind("SELECT " + reminderColumns + " FROM reminders WHERE item_id = ? AND before_minutes IS NOT NULL")
+	if err := tx.SelectContext(ctx, &reminders, selectQuery, itemId); err != nil {
+		return err
+	}
+	for _, reminder := range reminders {
+		remindAt := reminder.Schedule(date).UTC()
+		query := "UPDATE reminders SET remind_at = ? WHERE id = ?"
+		if remindAt.After(now) {
+			query = "UPDATE reminders SET remind_at = ?, sent_at = NULL, claimed_until = NULL WHERE id = ?"
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), remindAt, reminder.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
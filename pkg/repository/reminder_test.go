@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestReminderClaim(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			now := time.Now().UTC().Truncate(time.Second)
+
+			itemId, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Call mom", Date: now.Add(time.Hour), Tags: []string{"family"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			doneId, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Done", Date: now, IsDone: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			at := now.Add(-45 * time.Minute)
+			before := 90
+			dueId, err := repos.Reminder.Create(ctx, userId, itemId, todoListSber.Reminder{At: &at, RemindAt: at})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			offsetReminder := todoListSber.Reminder{BeforeMinutes: &before}
+			offsetReminder.RemindAt = offsetReminder.Schedule(now.Add(time.Hour))
+			if _, err := repos.Reminder.Create(ctx, userId, itemId, offsetReminder); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := repos.Reminder.Create(ctx, userId, doneId, todoListSber.Reminder{At: &at, RemindAt: at}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			reminders, err := repos.Reminder.GetByItem(ctx, userId, itemId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(reminders) != 2 || reminders[0].Id != dueId || !reminders[0].RemindAt.Equal(at) {
+				t.Fatalf("unexpected reminders %+v", reminders)
+			}
+
+			// The offset reminder fell due 30 minutes ago; the reminder of the done item never does.
+			due, err := repos.Reminder.Claim(ctx, now, time.Minute, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(due) != 2 || due[0].Id != reminders[0].Id || due[1].Id != reminders[1].Id {
+				t.Fatalf("expected both reminders of the undone item; got %+v", due)
+			}
+			if due[0].UserId != userId || due[0].Item.Title != "Call mom" || len(due[0].Item.Tags) != 1 {
+				t.Errorf("expected the reminder to carry its item; got %+v", due[0])
+			}
+
+			if due, err := repos.Reminder.Claim(ctx, now, time.Minute, 10); err != nil || len(due) != 0 {
+				t.Fatalf("expected claimed reminders to be skipped; got %+v, %v", due, err)
+			}
+			if err := repos.Reminder.MarkSent(ctx, dueId, now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			due, err = repos.Reminder.Claim(ctx, now.Add(2*time.Minute), time.Minute, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(due) != 1 || due[0].Id != reminders[1].Id {
+				t.Fatalf("expected only the undelivered reminder after the lease; got %+v", due)
+			}
+
+			// Moving the item later re-arms its offset reminder.
+			if err := repos.Reminder.MarkSent(ctx, reminders[1].Id, now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			later := now.Add(3 * time.Hour)
+			if err := repos.TodoItem.Update(ctx, userId, itemId, todoListSber.UpdateItemInput{Date: &later}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			reminders, err = repos.Reminder.GetByItem(ctx, userId, itemId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if rearmed := reminders[1]; rearmed.SentAt != nil || !rearmed.RemindAt.Equal(now.Add(90*time.Minute)) {
+				t.Errorf("expected the offset reminder to be re-armed; got %+v", rearmed)
+			}
+			if reminders[0].SentAt == nil {
+				t.Errorf("expected the absolute reminder to stay sent")
+			}
+		})
+	}
+}
+
+func TestReminderOwnership(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			aliceId := createTestUser(t, repos, "alice")
+			bobId := createTestUser(t, repos, "bob")
+			at := time.Now().UTC()
+
+			itemId, err := repos.TodoItem.Create(ctx, aliceId, todoListSber.TodoItem{Title: "Task", Date: at})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			id, err := repos.Reminder.Create(ctx, aliceId, itemId, todoListSber.Reminder{At: &at, RemindAt: at})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var notFound *todoListSber.NotFoundError
+			if _, err := repos.Reminder.Create(ctx, bobId, itemId, todoListSber.Reminder{At: &at, RemindAt: at}); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Create; got %v", err)
+			}
+			if _, err := repos.Reminder.GetByItem(ctx, bobId, itemId); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from GetByItem; got %v", err)
+			}
+			if err := repos.Reminder.Delete(ctx, bobId, itemId, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Delete; got %v", err)
+			}
+
+			if err := repos.TodoItem.Delete(ctx, aliceId, itemId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if due, err := repos.Reminder.Claim(ctx, at.Add(time.Minute), time.Minute, 10); err != nil || len(due) != 0 {
+				t.Errorf("expected reminders to be deleted with their item; got %+v, %v", due, err)
+			}
+		})
+	}
+}
@@ -1,27 +1,180 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"github.com/jmoiron/sqlx"
 	"time"
 	todoListSber "todo-list-sber"
 )
 
+type Authorization interface {
+	CreateUser(ctx context.Context, user todoListSber.User) (int, error)
+	GetUser(ctx context.Context, username string) (todoListSber.User, error)
+}
+
+// TodoItem methods only see the items owned by userId; items of other users are reported as not found.
+// GetSubtree returns the item followed by its subtasks at any depth ordered by date and id. Move puts
+// an item under input.ParentId, or at the top level when it is nil, and reports ErrItemCycle when
+// the parent is the item itself or one of its subtasks. Delete moves an item and its subtasks to the
+// trash, where no other method sees them. GetTrash pages through the trash, the most recently deleted
+// first. Restore brings back a trashed item with the subtasks deleted together with it and reports
+// ErrParentInTrash while its parent is still trashed. Purge deletes the items of all users trashed
+// before the given time for good and returns how many were trashed then. Every Update that changes
+// an item records a revision with the changed fields, which GetHistory returns the latest first.
 type TodoItem interface {
-	Create(item todoListSber.TodoItem) (int, error)
-	GetAll() ([]todoListSber.TodoItem, error)
-	GetById(id int) (todoListSber.TodoItem, error)
-	Delete(id int) error
-	Update(id int, input todoListSber.UpdateItemInput) error
-	GetDoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error)
-	GetUndoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error)
+	Create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error)
+	GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error)
+	Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error)
+	Delete(ctx context.Context, userId, id int) error
+	Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error
+	GetSubtree(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error)
+	Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error
+	GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error)
+	Restore(ctx context.Context, userId, id int) error
+	Purge(ctx context.Context, before time.Time) (int, error)
+	GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error)
+}
+
+// TodoList methods are scoped to userId like TodoItem. Delete moves the items of the list out of it,
+// or moves them to the trash together with their subtasks when cascade is set.
+type TodoList interface {
+	Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error)
+	Delete(ctx context.Context, userId, id int, cascade bool) error
+	Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error
+}
+
+// Tag methods are scoped to userId like TodoItem. Attach and Detach report a NotFoundError
+// when either the item or the tag belongs to another user.
+type Tag interface {
+	Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error)
+	Delete(ctx context.Context, userId, id int) error
+	Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error
+	Attach(ctx context.Context, userId, itemId, tagId int) error
+	Detach(ctx context.Context, userId, itemId, tagId int) error
+}
+
+// Reminder methods other than Claim and MarkSent are scoped to userId and report a NotFoundError
+// for the items of other users. Claim reserves up to limit unsent reminders of undone items that
+// are due at now for lease, so that concurrent schedulers never claim the same reminder; a claimed
+// reminder that is not marked sent within the lease becomes due again.
+type Reminder interface {
+	Create(ctx context.Context, userId, itemId int, reminder todoListSber.Reminder) (int, error)
+	GetByItem(ctx context.Context, userId, itemId int) ([]todoListSber.Reminder, error)
+	Delete(ctx context.Context, userId, itemId, id int) error
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.DueReminder, error)
+	MarkSent(ctx context.Context, id int, at time.Time) error
+}
+
+// Dependency methods are scoped to userId like TodoItem. Add makes blockerId block itemId and reports
+// ErrDependencyCycle when blockerId is itemId or is already blocked by it at any depth; GetBlockers
+// returns the items blocking itemId ordered by date and id.
+type Dependency interface {
+	GetBlockers(ctx context.Context, userId, itemId int) ([]todoListSber.TodoItem, error)
+	Add(ctx context.Context, userId, itemId, blockerId int) error
+	Remove(ctx context.Context, userId, itemId, blockerId int) error
+}
+
+// Webhook methods other than ClaimDeliveries and UpdateDelivery are scoped to userId. Enqueue queues
+// a delivery of payload to every webhook of userId subscribed to event. ClaimDeliveries reserves
+// pending deliveries due at now for lease like Reminder.Claim; UpdateDelivery records the outcome of
+// an attempt and releases the claim.
+type Webhook interface {
+	Create(ctx context.Context, userId int, webhook todoListSber.Webhook) (int, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.Webhook, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.Webhook, error)
+	Delete(ctx context.Context, userId, id int) error
+	Enqueue(ctx context.Context, userId int, event string, payload []byte, now time.Time) error
+	GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todoListSber.WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.PendingDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery todoListSber.WebhookDelivery) error
+}
+
+// EventLog buffers the latest item events of every user. Append stores an event and trims the
+// buffer of userId to the newest keep events; After returns up to limit events following afterId.
+// Latest is the id of the newest event of userId, or 0, and Has reports whether id is still buffered.
+type EventLog interface {
+	Append(ctx context.Context, userId int, event todoListSber.StoredEvent, keep int) (int, error)
+	After(ctx context.Context, userId, afterId, limit int) ([]todoListSber.StoredEvent, error)
+	Latest(ctx context.Context, userId int) (int, error)
+	Has(ctx context.Context, userId, id int) (bool, error)
 }
 
 type Repository struct {
+	Authorization
 	TodoItem
+	TodoList
+	Tag
+	Reminder
+	Dependency
+	Webhook
+	EventLog
+	Transactor
 }
 
+// NewRepository builds the SQL-backed repositories matching the driver db was opened with.
 func NewRepository(db *sqlx.DB) *Repository {
+	return newSQLRepository(sqlDB{db})
+}
+
+// newSQLRepository builds the SQL-backed repositories running their statements on conn.
+func newSQLRepository(conn dbConn) *Repository {
+	if conn.DriverName() == DriverSQLite {
+		return &Repository{
+			Authorization: &AuthSQLite{db: conn},
+			TodoItem:      &TodoItemSQLite{db: conn},
+			TodoList:      &TodoListSQLite{db: conn},
+			Tag:           &TagSQLite{db: conn},
+			Reminder:      &ReminderSQL{db: conn},
+			Dependency:    &DependencySQL{db: conn, dialect: sqliteDialect},
+			Webhook:       &WebhookSQL{db: conn},
+			EventLog:      &EventLogSQL{db: conn},
+			Transactor:    sqlTransactor{conn: conn},
+		}
+	}
 	return &Repository{
-		TodoItem: NewTodoItemPostgres(db),
+		Authorization: &AuthPostgres{db: conn},
+		TodoItem:      &TodoItemPostgres{db: conn},
+		TodoList:      &TodoListPostgres{db: conn},
+		Tag:           &TagPostgres{db: conn},
+		Reminder:      &ReminderSQL{db: conn},
+		Dependency:    &DependencySQL{db: conn, dialect: postgresDialect},
+		Webhook:       &WebhookSQL{db: conn},
+		EventLog:      &EventLogSQL{db: conn},
+		Transactor:    sqlTransactor{conn: conn},
+	}
+}
+
+func NewMemoryRepository() *Repository {
+	items := NewTodoItemMemory()
+	lists := NewTodoListMemory(items)
+	repos := &Repository{
+		Authorization: NewAuthMemory(),
+		TodoItem:      items,
+		TodoList:      lists,
+		Tag:           NewTagMemory(items),
+		Reminder:      NewReminderMemory(items),
+		Dependency:    NewDependencyMemory(items),
+		Webhook:       NewWebhookMemory(),
+		EventLog:      NewEventLogMemory(),
+	}
+	repos.Transactor = memoryTransactor{repos: repos, items: items, lists: lists}
+	return repos
+}
+
+// checkAffected reports notFound when an UPDATE or DELETE matched no rows.
+func checkAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
 	}
+	return nil
 }
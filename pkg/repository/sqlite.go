@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriver is the SQLite driver with the functions the repositories need registered on every
+// connection: unicode_lower lowercases all letters, where the builtin lower only knows ASCII.
+const sqliteDriver = "sqlite3_todo"
+
+func init() {
+	sql.Register(sqliteDriver, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("unicode_lower", lowerText, true)
+		},
+	})
+}
+
+// NewSQLiteDB opens the SQLite database file at cfg.Path. Transactions take the write lock up
+// front (_txlock=immediate) so that concurrent writers wait on busy_timeout instead of failing
+// with SQLITE_BUSY when they upgrade from a read lock.
+func NewSQLiteDB(cfg Config) (*sqlx.DB, error) {
+	conn, err := sql.Open(sqliteDriver, fmt.Sprintf("file:%s?_busy_timeout=5000&_foreign_keys=on&_journal_mode=WAL&_txlock=immediate", cfg.Path))
+	if err != nil {
+		return nil, err
+	}
+	db := sqlx.NewDb(conn, DriverSQLite)
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// isUniqueViolation reports whether err is a SQLite UNIQUE constraint failure.
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
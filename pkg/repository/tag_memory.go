@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	todoListSber "todo-list-sber"
+)
+
+// TagMemory serves tags out of the TodoItemMemory store, which owns them together with the items.
+type TagMemory struct {
+	items *TodoItemMemory
+}
+
+func NewTagMemory(items *TodoItemMemory) *TagMemory {
+	return &TagMemory{items: items}
+}
+func (r *TagMemory) Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error) {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if _, ok := r.items.findTag(userId, tag.Name); ok {
+		return 0, todoListSber.ErrTagExists
+	}
+	tag.Id = r.items.nextTagId
+	setEntry(r.items.undo, r.items.tags, tag.Id, tag)
+	setEntry(r.items.undo, r.items.tagOwners, tag.Id, userId)
+	r.items.nextTagId++
+	return tag.Id, nil
+}
+func (r *TagMemory) GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error) {
+	r.items.mu.RLock()
+	defer r.items.mu.RUnlock()
+
+	var tags []todoListSber.Tag
+	for id, tag := range r.items.tags {
+		if r.items.tagOwners[id] == userId {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	return tags, nil
+}
+func (r *TagMemory) GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error) {
+	r.items.mu.RLock()
+	defer r.items.mu.RUnlock()
+
+	tag, ok := r.items.tags[id]
+	if !ok || r.items.tagOwners[id] != userId {
+		return todoListSber.Tag{}, todoListSber.ErrTagNotFound(id)
+	}
+	return tag, nil
+}
+func (r *TagMemory) Delete(ctx context.Context, userId, id int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if _, ok := r.items.tags[id]; !ok || r.items.tagOwners[id] != userId {
+		return todoListSber.ErrTagNotFound(id)
+	}
+	return r.items.changeItems(userId, r.items.taggedItems(id), func() {
+		deleteEntry(r.items.undo, r.items.tags, id)
+		deleteEntry(r.items.undo, r.items.tagOwners, id)
+		for _, tagIds := range r.items.itemTags {
+			deleteEntry(r.items.undo, tagIds, id)
+		}
+	})
+}
+func (r *TagMemory) Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if _, ok := r.items.tags[id]; !ok || r.items.tagOwners[id] != userId {
+		return todoListSber.ErrTagNotFound(id)
+	}
+	if other, ok := r.items.findTag(userId, tag.Name); ok && other != id {
+		return todoListSber.ErrTagExists
+	}
+	tag.Id = id
+	return r.items.changeItems(userId, r.items.taggedItems(id), func() {
+		setEntry(r.items.undo, r.items.tags, id, tag)
+	})
+}
+func (r *TagMemory) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if err := r.checkItemAndTag(userId, itemId, tagId); err != nil {
+		return err
+	}
+	return r.items.changeItems(userId, []int{itemId}, func() {
+		r.items.attach(itemId, tagId)
+	})
+}
+func (r *TagMemory) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	r.items.mu.Lock()
+	defer r.items.mu.Unlock()
+
+	if err := r.checkItemAndTag(userId, itemId, tagId); err != nil {
+		return err
+	}
+	return r.items.changeItems(userId, []int{itemId}, func() {
+		deleteEntry(r.items.undo, r.items.itemTags[itemId], tagId)
+	})
+}
+
+func (r *TagMemory) checkItemAndTag(userId, itemId, tagId int) error {
+	if !r.items.owned(userId, itemId) {
+		return todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	if _, ok := r.items.tags[tagId]; !ok || r.items.tagOwners[tagId] != userId {
+		return todoListSber.ErrTagNotFound(tagId)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	todoListSber "todo-list-sber"
+)
+
+type TagPostgres struct {
+	db dbConn
+}
+
+func NewTagPostgres(db *sqlx.DB) *TagPostgres {
+	return &TagPostgres{db: sqlDB{db}}
+}
+func (r *TagPostgres) Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error) {
+	var id int
+	query := "INSERT INTO tags (user_id, name) VALUES ($1, $2) RETURNING id"
+	err := r.db.QueryRowxContext(ctx, query, userId, tag.Name).Scan(&id)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return 0, todoListSber.ErrTagExists
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+func (r *TagPostgres) GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error) {
+	var tags []todoListSber.Tag
+	query := "SELECT id, name FROM tags WHERE user_id = $1 ORDER BY name"
+	err := r.db.SelectContext(ctx, &tags, query, userId)
+	return tags, err
+}
+func (r *TagPostgres) GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error) {
+	var tag todoListSber.Tag
+	query := "SELECT id, name FROM tags WHERE id = $1 AND user_id = $2"
+	err := r.db.GetContext(ctx, &tag, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return tag, todoListSber.ErrTagNotFound(id)
+	}
+	return tag, err
+}
+func (r *TagPostgres) Delete(ctx context.Context, userId, id int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = changeItems(ctx, tx, userId, taggedItems, []interface{}{id}, func() error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = $1 AND user_id = $2", id, userId)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTagNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagPostgres) Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = changeItems(ctx, tx, userId, taggedItems, []interface{}{id}, func() error {
+		res, err := tx.ExecContext(ctx, "UPDATE tags SET name = $1 WHERE id = $2 AND user_id = $3", tag.Name, id, userId)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return todoListSber.ErrTagExists
+		}
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTagNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagPostgres) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkItemAndTag(ctx, tx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	query := "INSERT INTO todo_item_tags (item_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	err = changeItems(ctx, tx, userId, "id = ?", []interface{}{itemId}, func() error {
+		_, err := tx.ExecContext(ctx, query, itemId, tagId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagPostgres) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkItemAndTag(ctx, tx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	err = changeItems(ctx, tx, userId, "id = ?", []interface{}{itemId}, func() error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM todo_item_tags WHERE item_id = $1 AND tag_id = $2", itemId, tagId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
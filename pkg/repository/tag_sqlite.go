@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	todoListSber "todo-list-sber"
+)
+
+type TagSQLite struct {
+	db dbConn
+}
+
+func NewTagSQLite(db *sqlx.DB) *TagSQLite {
+	return &TagSQLite{db: sqlDB{db}}
+}
+func (r *TagSQLite) Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error) {
+	query := "INSERT INTO tags (user_id, name) VALUES (?, ?)"
+	res, err := r.db.ExecContext(ctx, query, userId, tag.Name)
+	if isUniqueViolation(err) {
+		return 0, todoListSber.ErrTagExists
+	}
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+func (r *TagSQLite) GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error) {
+	var tags []todoListSber.Tag
+	query := "SELECT id, name FROM tags WHERE user_id = ? ORDER BY name"
+	err := r.db.SelectContext(ctx, &tags, query, userId)
+	return tags, err
+}
+func (r *TagSQLite) GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error) {
+	var tag todoListSber.Tag
+	query := "SELECT id, name FROM tags WHERE id = ? AND user_id = ?"
+	err := r.db.GetContext(ctx, &tag, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return tag, todoListSber.ErrTagNotFound(id)
+	}
+	return tag, err
+}
+func (r *TagSQLite) Delete(ctx context.Context, userId, id int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = changeItems(ctx, tx, userId, taggedItems, []interface{}{id}, func() error {
+		res, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE id = ? AND user_id = ?", id, userId)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTagNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagSQLite) Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = changeItems(ctx, tx, userId, taggedItems, []interface{}{id}, func() error {
+		res, err := tx.ExecContext(ctx, "UPDATE tags SET name = ? WHERE id = ? AND user_id = ?", tag.Name, id, userId)
+		if isUniqueViolation(err) {
+			return todoListSber.ErrTagExists
+		}
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTagNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagSQLite) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkItemAndTag(ctx, tx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	query := "INSERT INTO todo_item_tags (item_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING"
+	err = changeItems(ctx, tx, userId, "id = ?", []interface{}{itemId}, func() error {
+		_, err := tx.ExecContext(ctx, query, itemId, tagId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TagSQLite) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkItemAndTag(ctx, tx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	err = changeItems(ctx, tx, userId, "id = ?", []interface{}{itemId}, func() error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM todo_item_tags WHERE item_id = ? AND tag_id = ?", itemId, tagId)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestTagFilter(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			items := []todoListSber.TodoItem{
+				{Title: "both", Date: date, Tags: []string{"work", "urgent"}},
+				{Title: "work", Date: date.Add(time.Hour), Tags: []string{"work", "work"}},
+				{Title: "urgent", Date: date.Add(2 * time.Hour), Tags: []string{"urgent"}},
+				{Title: "none", Date: date.Add(3 * time.Hour)},
+			}
+			for _, item := range items {
+				if _, err := repos.TodoItem.Create(ctx, userId, item); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			item, err := repos.TodoItem.GetById(ctx, userId, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(item.Tags, []string{"urgent", "work"}) {
+				t.Errorf("expected tags sorted by name; got %v", item.Tags)
+			}
+
+			tests := []struct {
+				name     string
+				filter   todoListSber.TagFilter
+				expected []string
+			}{
+				{name: "No Filter", expected: []string{"both", "work", "urgent", "none"}},
+				{name: "Any", filter: todoListSber.TagFilter{Tags: []string{"work", "urgent"}}, expected: []string{"both", "work", "urgent"}},
+				{name: "All", filter: todoListSber.TagFilter{Tags: []string{"work", "urgent"}, MatchAll: true}, expected: []string{"both"}},
+				{name: "All Repeated", filter: todoListSber.TagFilter{Tags: []string{"work", "work"}, MatchAll: true}, expected: []string{"both", "work"}},
+				{name: "Unknown", filter: todoListSber.TagFilter{Tags: []string{"home"}}, expected: nil},
+			}
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					page, err := repos.TodoItem.GetAll(ctx, userId, todoListSber.TodoItemQuery{Tags: test.filter, Sort: todoListSber.SortByDate})
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+					var titles []string
+					for _, item := range page.Items {
+						titles = append(titles, item.Title)
+					}
+					if !reflect.DeepEqual(titles, test.expected) {
+						t.Errorf("expected %v; got %v", test.expected, titles)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestTagCRUD(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			otherId := createTestUser(t, repos, "bob")
+
+			itemId, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Task", Date: time.Now(), Tags: []string{"home"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tagId, err := repos.Tag.Create(ctx, userId, todoListSber.Tag{Name: "work"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var conflict *todoListSber.ConflictError
+			if _, err := repos.Tag.Create(ctx, userId, todoListSber.Tag{Name: "work"}); !errors.As(err, &conflict) {
+				t.Errorf("expected ConflictError for a duplicate tag; got %v", err)
+			}
+			if err := repos.Tag.Update(ctx, userId, tagId, todoListSber.Tag{Name: "home"}); !errors.As(err, &conflict) {
+				t.Errorf("expected ConflictError when renaming onto an existing tag; got %v", err)
+			}
+			if _, err := repos.Tag.Create(ctx, otherId, todoListSber.Tag{Name: "work"}); err != nil {
+				t.Errorf("expected tag names to be unique per user; got %s", err)
+			}
+
+			if err := repos.Tag.Attach(ctx, userId, itemId, tagId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repos.Tag.Attach(ctx, userId, itemId, tagId); err != nil {
+				t.Fatalf("expected attaching twice to succeed; got %s", err)
+			}
+			assertTags := func(expected []string) {
+				t.Helper()
+				item, err := repos.TodoItem.GetById(ctx, userId, itemId)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(item.Tags, expected) {
+					t.Errorf("expected tags %v; got %v", expected, item.Tags)
+				}
+			}
+			assertTags([]string{"home", "work"})
+			if item, err := repos.TodoItem.GetById(ctx, userId, itemId); err != nil || item.Version != 2 {
+				t.Errorf("expected only the first attach to bump the version; got %+v, %v", item, err)
+			}
+
+			if err := repos.Tag.Update(ctx, userId, tagId, todoListSber.Tag{Name: "office"}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assertTags([]string{"home", "office"})
+
+			if err := repos.Tag.Detach(ctx, userId, itemId, tagId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assertTags([]string{"home"})
+
+			tags := []string{"garden"}
+			if err := repos.TodoItem.Update(ctx, userId, itemId, todoListSber.UpdateItemInput{Tags: &tags}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assertTags([]string{"garden"})
+
+			var notFound *todoListSber.NotFoundError
+			if err := repos.Tag.Attach(ctx, otherId, itemId, tagId); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError attaching to another user's item; got %v", err)
+			}
+			if err := repos.Tag.Delete(ctx, otherId, tagId); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError deleting another user's tag; got %v", err)
+			}
+
+			all, err := repos.Tag.GetAll(ctx, userId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var names []string
+			for _, tag := range all {
+				names = append(names, tag.Name)
+			}
+			if !reflect.DeepEqual(names, []string{"garden", "home", "office"}) {
+				t.Errorf("unexpected tags: %v", names)
+			}
+
+			gardenId := all[0].Id
+			if err := repos.Tag.Delete(ctx, userId, gardenId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			assertTags(nil)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	todoListSber "todo-list-sber"
+)
+
+// The helpers below are shared by the Postgres and SQLite repositories. Queries are written
+// with "?" placeholders and rebound for the driver in use.
+
+// tagFilterClause returns the condition that restricts todo_items to filter, or an empty string
+// when the filter has no tags.
+func tagFilterClause(filter todoListSber.TagFilter) (string, []interface{}) {
+	names := uniqueNames(filter.Tags)
+	if len(names) == 0 {
+		return "", nil
+	}
+	args := make([]interface{}, 0, len(names)+1)
+	for _, name := range names {
+		args = append(args, name)
+	}
+	clause := " AND id IN (SELECT it.item_id FROM todo_item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name IN (?" +
+		strings.Repeat(", ?", len(names)-1) + ")"
+	if filter.MatchAll {
+		clause += " GROUP BY it.item_id HAVING COUNT(*) = ?"
+		args = append(args, len(names))
+	}
+	return clause + ")", args
+}
+
+// loadTags fills in the tag names of items, sorted by name.
+func loadTags(ctx context.Context, q sqlx.ExtContext, items []todoListSber.TodoItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int, len(items))
+	byId := make(map[int]*todoListSber.TodoItem, len(items))
+	for i := range items {
+		ids[i] = items[i].Id
+		byId[items[i].Id] = &items[i]
+	}
+	query, args, err := sqlx.In("SELECT it.item_id, t.name FROM todo_item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id IN (?) ORDER BY t.name", ids)
+	if err != nil {
+		return err
+	}
+	var rows []struct {
+		ItemId int    `db:"item_id"`
+		Name   string `db:"name"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &rows, q.Rebind(query), args...); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		item := byId[row.ItemId]
+		item.Tags = append(item.Tags, row.Name)
+	}
+	return nil
+}
+
+// taggedItems matches the items tagged with a tag for changeItems.
+const taggedItems = "id IN (SELECT item_id FROM todo_item_tags WHERE tag_id = ?)"
+
+// setItemTags replaces the tags of an item, creating the tags the user does not have yet.
+func setItemTags(ctx context.Context, tx dbTx, userId, itemId int, names []string) error {
+	if _, err := tx.ExecContext(ctx, tx.Rebind("DELETE FROM todo_item_tags WHERE item_id = ?"), itemId); err != nil {
+		return err
+	}
+	for _, name := range uniqueNames(names) {
+		_, err := tx.ExecContext(ctx, tx.Rebind("INSERT INTO tags (user_id, name) VALUES (?, ?) ON CONFLICT (user_id, name) DO NOTHING"), userId, name)
+		if err != nil {
+			return err
+		}
+		var tagId int
+		if err := tx.GetContext(ctx, &tagId, tx.Rebind("SELECT id FROM tags WHERE user_id = ? AND name = ?"), userId, name); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind("INSERT INTO todo_item_tags (item_id, tag_id) VALUES (?, ?)"), itemId, tagId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkItemAndTag makes sure both the item and the tag belong to the user.
+func checkItemAndTag(ctx context.Context, tx dbTx, userId, itemId, tagId int) error {
+	var id int
+	err := tx.GetContext(ctx, &id, tx.Rebind("SELECT id FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL"), itemId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoListSber.ErrTodoItemNotFound(itemId)
+	}
+	if err != nil {
+		return err
+	}
+	err = tx.GetContext(ctx, &id, tx.Rebind("SELECT id FROM tags WHERE id = ? AND user_id = ?"), tagId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoListSber.ErrTagNotFound(tagId)
+	}
+	return err
+}
+
+func uniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		unique = append(unique, name)
+	}
+	return unique
+}
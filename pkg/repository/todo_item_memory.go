@@ -0,0 +1,599 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// TodoItemMemory keeps todo items in process memory. It mirrors the behaviour of
+// TodoItemPostgres and is safe for concurrent use. Tags, reminders and blockers live here too,
+// so that all item related changes are guarded by the same lock.
+type TodoItemMemory struct {
+	*itemStore
+	mu rwLocker
+	// undo records the changes made inside a transaction, see memoryTransactor.
+	undo *undoLog
+}
+
+// itemStore is the data of TodoItemMemory, shared with the views of its transactions.
+type itemStore struct {
+	items          map[int]todoListSber.TodoItem
+	owners         map[int]int
+	nextId         int
+	tags           map[int]todoListSber.Tag
+	tagOwners      map[int]int
+	itemTags       map[int]map[int]bool
+	nextTagId      int
+	reminders      map[int]memoryReminder
+	nextReminderId int
+	// blockers maps an item to the set of items blocking it.
+	blockers map[int]map[int]bool
+	// revisions holds the revisions of every item, the oldest first.
+	revisions map[int][]todoListSber.Revision
+}
+
+type memoryReminder struct {
+	todoListSber.Reminder
+	userId       int
+	claimedUntil time.Time
+}
+
+func NewTodoItemMemory() *TodoItemMemory {
+	return &TodoItemMemory{mu: &sync.RWMutex{}, itemStore: &itemStore{
+		items:          make(map[int]todoListSber.TodoItem),
+		owners:         make(map[int]int),
+		nextId:         1,
+		tags:           make(map[int]todoListSber.Tag),
+		tagOwners:      make(map[int]int),
+		itemTags:       make(map[int]map[int]bool),
+		nextTagId:      1,
+		reminders:      make(map[int]memoryReminder),
+		nextReminderId: 1,
+		blockers:       make(map[int]map[int]bool),
+		revisions:      make(map[int][]todoListSber.Revision),
+	}}
+}
+func (r *TodoItemMemory) Create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item.Id = r.nextId
+	if item.ListId != nil {
+		listId := *item.ListId
+		item.ListId = &listId
+	}
+	if item.ParentId != nil {
+		parentId := *item.ParentId
+		item.ParentId = &parentId
+	}
+	item.Subtasks = nil
+	item.NextId = nil
+	item.DeletedAt = nil
+	item.Version = 1
+	r.setItemTags(userId, item.Id, item.Tags)
+	item.Tags = nil
+	setEntry(r.undo, r.items, item.Id, item)
+	setEntry(r.undo, r.owners, item.Id, userId)
+	r.nextId++
+	return item.Id, nil
+}
+
+// GetAll reproduces the SQL rendering of query, see itemQueryDialect.
+func (r *TodoItemMemory) GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var todoItems []todoListSber.TodoItem
+	for _, item := range r.items {
+		if !r.owned(userId, item.Id) || !r.matchQuery(item, query) {
+			continue
+		}
+		todoItems = append(todoItems, r.withTags(item))
+	}
+	sortItems(todoItems, query)
+
+	page := todoListSber.TodoItemPage{}
+	if !query.SkipTotal {
+		page.Total = len(todoItems)
+	}
+	if query.Limit == 0 {
+		page.Items = todoItems
+		return page, nil
+	}
+	if query.Offset >= len(todoItems) {
+		return page, nil
+	}
+	todoItems = todoItems[query.Offset:]
+	if query.Limit < len(todoItems) {
+		todoItems = todoItems[:query.Limit]
+	}
+	page.Items = todoItems
+	return page, nil
+}
+func (r *TodoItemMemory) Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var todoItems []todoListSber.TodoItem
+	for _, item := range r.items {
+		if r.owned(userId, item.Id) {
+			todoItems = append(todoItems, r.withTags(item))
+		}
+	}
+	return searchItems(todoItems, query), nil
+}
+func (r *TodoItemMemory) GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.items[id]
+	if !ok || !r.owned(userId, id) {
+		return todoListSber.TodoItem{}, todoListSber.ErrTodoItemNotFound(id)
+	}
+	return r.withTags(item), nil
+}
+func (r *TodoItemMemory) Delete(ctx context.Context, userId, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.owned(userId, id) {
+		return todoListSber.ErrTodoItemNotFound(id)
+	}
+	r.trash(id, time.Now())
+	return nil
+}
+func (r *TodoItemMemory) Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || !r.owned(userId, id) {
+		return todoListSber.ErrTodoItemNotFound(id)
+	}
+	if input.Version != nil && *input.Version != item.Version {
+		return todoListSber.ErrVersionMismatch(id, item.Version)
+	}
+	return r.changeItems(userId, []int{id}, func() {
+		if input.Title != nil {
+			item.Title = *input.Title
+		}
+		if input.Description != nil {
+			item.Description = *input.Description
+		}
+		if input.IsDone != nil {
+			item.IsDone = *input.IsDone
+		}
+		if input.Date != nil {
+			item.Date = *input.Date
+			r.rescheduleReminders(id, item.Date, time.Now())
+		}
+		if input.Priority != nil {
+			item.Priority = *input.Priority
+		}
+		if input.ListId != nil {
+			listId := *input.ListId
+			item.ListId = &listId
+		} else if input.ClearList {
+			item.ListId = nil
+		}
+		if input.Recurrence != nil {
+			item.Recurrence = *input.Recurrence
+		}
+		if input.Timezone != nil {
+			item.Timezone = *input.Timezone
+		}
+		if input.NextId != nil {
+			nextId := *input.NextId
+			item.NextId = &nextId
+		}
+		if input.Tags != nil {
+			r.setItemTags(userId, id, *input.Tags)
+		}
+		setEntry(r.undo, r.items, id, item)
+	})
+}
+
+// GetSubtree orders the subtasks like the recursive query of itemQueryDialect.selectSubtree.
+func (r *TodoItemMemory) GetSubtree(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	root, ok := r.items[id]
+	if !ok || !r.owned(userId, id) {
+		return nil, todoListSber.ErrTodoItemNotFound(id)
+	}
+	var subtasks []todoListSber.TodoItem
+	for _, subtaskId := range r.subtaskIds(id) {
+		if r.items[subtaskId].DeletedAt == nil {
+			subtasks = append(subtasks, r.withTags(r.items[subtaskId]))
+		}
+	}
+	sortItems(subtasks, todoListSber.TodoItemQuery{Sort: todoListSber.SortByDate})
+	return append([]todoListSber.TodoItem{r.withTags(root)}, subtasks...), nil
+}
+func (r *TodoItemMemory) Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || !r.owned(userId, id) {
+		return todoListSber.ErrTodoItemNotFound(id)
+	}
+	if input.Version != nil && *input.Version != item.Version {
+		return todoListSber.ErrVersionMismatch(id, item.Version)
+	}
+	item.ParentId = nil
+	if parentId := input.ParentId; parentId != nil {
+		if !r.owned(userId, *parentId) {
+			return todoListSber.ErrTodoItemNotFound(*parentId)
+		}
+		for ancestor := parentId; ancestor != nil; ancestor = r.items[*ancestor].ParentId {
+			if *ancestor == id {
+				return todoListSber.ErrItemCycle
+			}
+		}
+		parent := *parentId
+		item.ParentId = &parent
+	}
+	return r.changeItems(userId, []int{id}, func() {
+		setEntry(r.undo, r.items, id, item)
+	})
+}
+
+func (r *TodoItemMemory) GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var trashed []todoListSber.TodoItem
+	for id, item := range r.items {
+		if r.owners[id] == userId && item.DeletedAt != nil {
+			trashed = append(trashed, r.withTags(item))
+		}
+	}
+	sort.Slice(trashed, func(i, j int) bool {
+		a, b := trashed[i], trashed[j]
+		if !a.DeletedAt.Equal(*b.DeletedAt) {
+			return a.DeletedAt.After(*b.DeletedAt)
+		}
+		return a.Id < b.Id
+	})
+	if offset >= len(trashed) {
+		return nil, nil
+	}
+	trashed = trashed[offset:]
+	if limit < len(trashed) {
+		trashed = trashed[:limit]
+	}
+	return trashed, nil
+}
+
+// Restore brings back the subtasks deleted together with the item, see restore.
+func (r *TodoItemMemory) Restore(ctx context.Context, userId, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || r.owners[id] != userId || item.DeletedAt == nil {
+		return todoListSber.ErrTodoItemNotFound(id)
+	}
+	if item.ParentId != nil && r.items[*item.ParentId].DeletedAt != nil {
+		return todoListSber.ErrParentInTrash
+	}
+	r.restore(id, *item.DeletedAt)
+	return nil
+}
+func (r *TodoItemMemory) Purge(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged []int
+	for id, item := range r.items {
+		if item.DeletedAt != nil && item.DeletedAt.Before(before) {
+			purged = append(purged, id)
+		}
+	}
+	for _, id := range purged {
+		if _, ok := r.items[id]; ok {
+			r.deleteItem(id)
+		}
+	}
+	return len(purged), nil
+}
+func (r *TodoItemMemory) GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.owned(userId, id) {
+		return nil, todoListSber.ErrTodoItemNotFound(id)
+	}
+	var revisions []todoListSber.Revision
+	for i := len(r.revisions[id]) - 1; i >= 0; i-- {
+		revisions = append(revisions, r.revisions[id][i])
+	}
+	return revisions, nil
+}
+
+// removeList detaches the items of listId of userId, after moving them to the trash when cascade
+// is set.
+func (r *TodoItemMemory) removeList(userId, listId int, cascade bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []int
+	for id, item := range r.items {
+		if item.ListId != nil && *item.ListId == listId {
+			ids = append(ids, id)
+		}
+	}
+	now := time.Now()
+	return r.changeItems(userId, ids, func() {
+		for _, id := range ids {
+			if cascade && r.items[id].DeletedAt == nil {
+				r.trash(id, now)
+			}
+			item := r.items[id]
+			item.ListId = nil
+			setEntry(r.undo, r.items, id, item)
+		}
+	})
+}
+
+// matchQuery applies the filters of query. Days are compared on the wall clock of the
+// stored timestamp, as Postgres does when casting a TIMESTAMP column to date.
+func (r *TodoItemMemory) matchQuery(item todoListSber.TodoItem, query todoListSber.TodoItemQuery) bool {
+	if query.IsDone != nil && item.IsDone != *query.IsDone {
+		return false
+	}
+	day := item.Date.Format("2006-01-02")
+	if query.From != nil && day < query.From.Format("2006-01-02") {
+		return false
+	}
+	if query.To != nil && day > query.To.Format("2006-01-02") {
+		return false
+	}
+	if query.Text != "" {
+		text := strings.ToLower(query.Text)
+		if !strings.Contains(strings.ToLower(item.Title), text) && !strings.Contains(strings.ToLower(item.Description), text) {
+			return false
+		}
+	}
+	if query.ListId != nil && (item.ListId == nil || *item.ListId != *query.ListId) {
+		return false
+	}
+	if query.Ready && (item.IsDone || r.blocked(item.Id)) {
+		return false
+	}
+	if query.After != nil && !afterCursor(item, *query.After, query.Desc) {
+		return false
+	}
+	return r.matchTags(item.Id, query.Tags)
+}
+
+// subtaskIds returns the ids of the subtasks of id at any depth.
+func (r *TodoItemMemory) subtaskIds(id int) []int {
+	var ids []int
+	for subtaskId, item := range r.items {
+		if item.ParentId != nil && *item.ParentId == id {
+			ids = append(ids, subtaskId)
+			ids = append(ids, r.subtaskIds(subtaskId)...)
+		}
+	}
+	return ids
+}
+
+// deleteItem deletes the item together with its subtasks, like "ON DELETE CASCADE" of
+// todo_items.parent_id, and unlinks the occurrences it followed, like "ON DELETE SET NULL" of
+// todo_items.next_id.
+func (r *TodoItemMemory) deleteItem(id int) {
+	for _, itemId := range append(r.subtaskIds(id), id) {
+		deleteEntry(r.undo, r.items, itemId)
+		deleteEntry(r.undo, r.owners, itemId)
+		deleteEntry(r.undo, r.itemTags, itemId)
+		deleteEntry(r.undo, r.blockers, itemId)
+		deleteEntry(r.undo, r.revisions, itemId)
+		for _, blockers := range r.blockers {
+			deleteEntry(r.undo, blockers, itemId)
+		}
+		for reminderId, reminder := range r.reminders {
+			if reminder.ItemId == itemId {
+				deleteEntry(r.undo, r.reminders, reminderId)
+			}
+		}
+		for previousId, previous := range r.items {
+			if previous.NextId != nil && *previous.NextId == itemId {
+				previous.NextId = nil
+				setEntry(r.undo, r.items, previousId, previous)
+			}
+		}
+	}
+}
+
+// owned reports whether id is an item of userId that is not in the trash.
+func (r *TodoItemMemory) owned(userId, id int) bool {
+	item, ok := r.items[id]
+	return ok && r.owners[id] == userId && item.DeletedAt == nil
+}
+
+// trash moves the item and its subtasks that are not in the trash yet to the trash at now.
+func (r *TodoItemMemory) trash(id int, now time.Time) {
+	deletedAt := now.UTC()
+	for _, itemId := range append(r.subtaskIds(id), id) {
+		item := r.items[itemId]
+		if item.DeletedAt == nil {
+			item.DeletedAt = &deletedAt
+			setEntry(r.undo, r.items, itemId, item)
+		}
+	}
+}
+
+// restore takes the item out of the trash together with the subtasks deleted at the same time.
+func (r *TodoItemMemory) restore(id int, deletedAt time.Time) {
+	item := r.items[id]
+	item.DeletedAt = nil
+	setEntry(r.undo, r.items, id, item)
+	for subtaskId, subtask := range r.items {
+		if subtask.ParentId != nil && *subtask.ParentId == id && subtask.DeletedAt != nil && subtask.DeletedAt.Equal(deletedAt) {
+			r.restore(subtaskId, deletedAt)
+		}
+	}
+}
+
+// blocked reports whether an item has open blockers.
+func (r *TodoItemMemory) blocked(id int) bool {
+	for blockerId := range r.blockers[id] {
+		if blocker := r.items[blockerId]; !blocker.IsDone && blocker.DeletedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// withTags returns a copy of item with its tag names sorted like the SQL repositories return them.
+func (r *TodoItemMemory) withTags(item todoListSber.TodoItem) todoListSber.TodoItem {
+	item.Tags = nil
+	for tagId := range r.itemTags[item.Id] {
+		item.Tags = append(item.Tags, r.tags[tagId].Name)
+	}
+	sort.Strings(item.Tags)
+	return item
+}
+
+func (r *TodoItemMemory) matchTags(itemId int, filter todoListSber.TagFilter) bool {
+	names := uniqueNames(filter.Tags)
+	if len(names) == 0 {
+		return true
+	}
+	matched := 0
+	for tagId := range r.itemTags[itemId] {
+		for _, name := range names {
+			if r.tags[tagId].Name == name {
+				matched++
+			}
+		}
+	}
+	if filter.MatchAll {
+		return matched == len(names)
+	}
+	return matched > 0
+}
+
+// setItemTags replaces the tags of an item, creating the tags the user does not have yet.
+func (r *TodoItemMemory) setItemTags(userId, itemId int, names []string) {
+	deleteEntry(r.undo, r.itemTags, itemId)
+	for _, name := range uniqueNames(names) {
+		tagId, ok := r.findTag(userId, name)
+		if !ok {
+			tagId = r.nextTagId
+			setEntry(r.undo, r.tags, tagId, todoListSber.Tag{Id: tagId, Name: name})
+			setEntry(r.undo, r.tagOwners, tagId, userId)
+			r.nextTagId++
+		}
+		r.attach(itemId, tagId)
+	}
+}
+
+func (r *TodoItemMemory) findTag(userId int, name string) (int, bool) {
+	for id, tag := range r.tags {
+		if r.tagOwners[id] == userId && tag.Name == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// changeItems runs change, a change of the items ids. Every item whose fields it changed gets a
+// revision by actorId and moves to its next version; the others are left as they are.
+func (r *TodoItemMemory) changeItems(actorId int, ids []int, change func()) error {
+	before := make([]todoListSber.TodoItem, len(ids))
+	for i, id := range ids {
+		before[i] = r.withTags(r.items[id])
+	}
+	change()
+	now := time.Now()
+	for _, item := range before {
+		changes, err := todoListSber.DiffItems(item, r.withTags(r.items[item.Id]))
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		changed := r.items[item.Id]
+		changed.Version++
+		setEntry(r.undo, r.items, item.Id, changed)
+		r.addRevision(actorId, item.Id, changes, now)
+	}
+	return nil
+}
+
+func (r *TodoItemMemory) addRevision(actorId, id int, changes []todoListSber.FieldChange, now time.Time) {
+	setEntry(r.undo, r.revisions, id, append(r.revisions[id], todoListSber.Revision{
+		Revision:  len(r.revisions[id]) + 1,
+		ItemId:    id,
+		ActorId:   actorId,
+		Changes:   changes,
+		CreatedAt: now.UTC(),
+	}))
+}
+
+// taggedItems returns the ids of the items tagged with tagId.
+func (r *TodoItemMemory) taggedItems(tagId int) []int {
+	var ids []int
+	for itemId, tagIds := range r.itemTags {
+		if tagIds[tagId] {
+			ids = append(ids, itemId)
+		}
+	}
+	return ids
+}
+
+func (r *TodoItemMemory) attach(itemId, tagId int) {
+	if r.itemTags[itemId] == nil {
+		setEntry(r.undo, r.itemTags, itemId, make(map[int]bool))
+	}
+	setEntry(r.undo, r.itemTags[itemId], tagId, true)
+}
+
+// afterCursor reports whether item comes strictly after cursor in the (date, id) order,
+// or strictly before it when desc is set.
+func afterCursor(item todoListSber.TodoItem, cursor todoListSber.ItemCursor, desc bool) bool {
+	after := item.Date.After(cursor.Date) || item.Date.Equal(cursor.Date) && item.Id > cursor.Id
+	before := item.Date.Before(cursor.Date) || item.Date.Equal(cursor.Date) && item.Id < cursor.Id
+	if desc {
+		return before
+	}
+	return after
+}
+
+// sortItems orders items like orderBy does in SQL.
+func sortItems(todoItems []todoListSber.TodoItem, query todoListSber.TodoItemQuery) {
+	sort.Slice(todoItems, func(i, j int) bool {
+		a, b := todoItems[i], todoItems[j]
+		if query.Desc {
+			a, b = b, a
+		}
+		switch query.Sort {
+		case todoListSber.SortByDate:
+			if !a.Date.Equal(b.Date) {
+				return a.Date.Before(b.Date)
+			}
+		case todoListSber.SortByTitle:
+			if a.Title != b.Title {
+				return a.Title < b.Title
+			}
+		case todoListSber.SortById:
+		default:
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+			if !a.Date.Equal(b.Date) {
+				return a.Date.Before(b.Date)
+			}
+		}
+		return a.Id < b.Id
+	})
+}
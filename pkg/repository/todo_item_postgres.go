@@ -1,117 +1,129 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
-	"log"
 	"strings"
 	"time"
 	todoListSber "todo-list-sber"
 )
 
 type TodoItemPostgres struct {
-	db *sqlx.DB
+	db dbConn
 }
 
 func NewTodoItemPostgres(db *sqlx.DB) *TodoItemPostgres {
-	return &TodoItemPostgres{db: db}
+	return &TodoItemPostgres{db: sqlDB{db}}
 }
-func (r *TodoItemPostgres) Create(item todoListSber.TodoItem) (int, error) {
+func (r *TodoItemPostgres) Create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error) {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback()
+
 	var id int
-	createTodoItemQuery := "INSERT INTO todo_items (title, description, date, is_done) VALUES ($1, $2, $3, $4) RETURNING id;"
-	err := r.db.QueryRowx(createTodoItemQuery, item.Title, item.Description, item.Date, item.IsDone).Scan(&id)
+	createTodoItemQuery := "INSERT INTO todo_items (user_id, title, description, date, is_done, priority, list_id, parent_id, recurrence, timezone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id;"
+	err = tx.QueryRowxContext(ctx, createTodoItemQuery, userId, item.Title, item.Description, item.Date, item.IsDone, item.Priority, item.ListId, item.ParentId, item.Recurrence, item.Timezone).Scan(&id)
 	if err != nil {
 		return -1, err
 	}
-	return id, nil
+	if err := setItemTags(ctx, tx, userId, id, item.Tags); err != nil {
+		return -1, err
+	}
+	return id, tx.Commit()
 }
-func (r *TodoItemPostgres) GetAll() ([]todoListSber.TodoItem, error) {
-	var todoItems []todoListSber.TodoItem
-	query := fmt.Sprintf("SELECT id, title, description, date, is_done FROM todo_items")
-	err := r.db.Select(&todoItems, query)
-	return todoItems, err
+func (r *TodoItemPostgres) GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error) {
+	return postgresDialect.selectPage(ctx, r.db, userId, query)
 }
-func (r *TodoItemPostgres) GetById(id int) (todoListSber.TodoItem, error) {
+func (r *TodoItemPostgres) Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error) {
+	// Without a language the query is parsed with both configurations, as the search column holds
+	// the lexemes of both. The russian configuration stems latin words in english as well, which
+	// makes it the better default for highlighting.
+	languages := []string{todoListSber.SearchLanguageRussian, todoListSber.SearchLanguageEnglish}
+	if query.Language == todoListSber.SearchLanguageEnglish {
+		languages = languages[1:]
+	} else if query.Language == todoListSber.SearchLanguageRussian {
+		languages = languages[:1]
+	}
+	tsqueries := make([]string, len(languages))
+	for i, language := range languages {
+		tsqueries[i] = fmt.Sprintf("websearch_to_tsquery('%s', $2)", language)
+	}
 
-	var todoItem todoListSber.TodoItem
-	query := fmt.Sprintf("SELECT id, title, description, date, is_done FROM todo_items where id = $1")
-	err := r.db.Get(&todoItem, query, id)
-	return todoItem, err
-}
-func (r *TodoItemPostgres) Delete(id int) error {
-	query := fmt.Sprintf("DELETE FROM todo_items where id = $1")
-	_, err := r.db.Exec(query, id)
-	return err
-}
-func (r *TodoItemPostgres) Update(id int, input todoListSber.UpdateItemInput) error {
-	setValues := make([]string, 0)
-	args := make([]interface{}, 0)
-	argId := 1
+	// Snippets are built in the outer query so that ts_headline only runs for the returned page.
+	args := []interface{}{userId, query.Text}
+	limit := ""
+	if query.Limit > 0 {
+		limit = "LIMIT $3 OFFSET $4"
+		args = append(args, query.Limit, query.Offset)
+	}
+	searchQuery := fmt.Sprintf(`SELECT %[1]s, rank,
+		ts_headline('%[2]s', title || ' ' || coalesce(description, ''), query, 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+		FROM (
+			SELECT %[1]s, ts_rank(search, q.query) AS rank, q.query
+			FROM todo_items CROSS JOIN (SELECT %[3]s AS query) AS q
+			WHERE user_id = $1 AND deleted_at IS NULL AND search @@ q.query
+			ORDER BY rank DESC, id
+			%[4]s
+		) AS hits
+		ORDER BY rank DESC, id`, todoItemColumns, languages[0], strings.Join(tsqueries, " || "), limit)
 
-	if input.Title != nil {
-		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
-		args = append(args, *input.Title)
-		argId++
+	var results []todoListSber.SearchResult
+	if err := r.db.SelectContext(ctx, &results, searchQuery, args...); err != nil {
+		return nil, err
 	}
-	if input.Description != nil {
-		setValues = append(setValues, fmt.Sprintf("description=$%d", argId))
-		args = append(args, *input.Description)
-		argId++
+	items := make([]todoListSber.TodoItem, len(results))
+	for i := range results {
+		items[i] = results[i].TodoItem
 	}
-	if input.IsDone != nil {
-		setValues = append(setValues, fmt.Sprintf("is_done=$%d", argId))
-		args = append(args, *input.IsDone)
-		argId++
+	if err := loadTags(ctx, r.db, items); err != nil {
+		return nil, err
 	}
-	if input.Date != nil {
-		setValues = append(setValues, fmt.Sprintf("date=$%d", argId))
-		args = append(args, *input.Date)
-		argId++
+	for i := range results {
+		results[i].TodoItem = items[i]
 	}
-	setQuery := strings.Join(setValues, ", ")
-
-	query := fmt.Sprintf("UPDATE todo_items SET %s WHERE id = $%d", setQuery, argId)
-	args = append(args, id)
-	log.Printf("SQL Query: %s", query)
-	log.Printf("Arguments: %v", args)
-	_, err := r.db.Exec(query, args...)
-	return err
+	return results, nil
 }
-func (r *TodoItemPostgres) GetDoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error) {
-
-	var todoItems []todoListSber.TodoItem
-	query := "SELECT id, title, description, date, is_done FROM todo_items"
-	args := []interface{}{}
+func (r *TodoItemPostgres) GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error) {
 
-	if date != nil {
-		query += " WHERE date::date = $1 AND is_done = true"
-		args = append(args, *date)
-	} else {
-		query += " WHERE is_done = $1"
-		args = append(args, true)
+	var todoItem todoListSber.TodoItem
+	query := fmt.Sprintf("SELECT %s FROM todo_items where id = $1 AND user_id = $2 AND deleted_at IS NULL", todoItemColumns)
+	err := r.db.GetContext(ctx, &todoItem, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoItem, todoListSber.ErrTodoItemNotFound(id)
 	}
-	query += " ORDER BY date OFFSET $2 LIMIT $3"
-	args = append(args, offset, limit)
-
-	err := r.db.Select(&todoItems, query, args...)
-	return todoItems, err
-}
-func (r *TodoItemPostgres) GetUndoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error) {
-
-	var todoItems []todoListSber.TodoItem
-	query := "SELECT id, title, description, date, is_done FROM todo_items"
-	args := []interface{}{}
-
-	if date != nil {
-		query += " WHERE date::date = $1 AND is_done = false"
-		args = append(args, *date)
-	} else {
-		query += " WHERE is_done = $1"
-		args = append(args, false)
+	if err != nil {
+		return todoItem, err
 	}
-	query += " ORDER BY date OFFSET $2 LIMIT $3"
-	args = append(args, offset, limit)
-
-	err := r.db.Select(&todoItems, query, args...)
-	return todoItems, err
+	items := []todoListSber.TodoItem{todoItem}
+	err = loadTags(ctx, r.db, items)
+	return items[0], err
+}
+func (r *TodoItemPostgres) Delete(ctx context.Context, userId, id int) error {
+	return trashSubtree(ctx, r.db, subtreeQuery, []interface{}{id, userId}, time.Now(), todoListSber.ErrTodoItemNotFound(id))
+}
+func (r *TodoItemPostgres) Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	return postgresDialect.update(ctx, r.db, userId, id, input)
+}
+func (r *TodoItemPostgres) GetSubtree(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error) {
+	return postgresDialect.selectSubtree(ctx, r.db, userId, id)
+}
+func (r *TodoItemPostgres) Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error {
+	return postgresDialect.move(ctx, r.db, userId, id, input)
+}
+func (r *TodoItemPostgres) GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error) {
+	return selectTrash(ctx, r.db, userId, limit, offset)
+}
+func (r *TodoItemPostgres) Restore(ctx context.Context, userId, id int) error {
+	return restore(ctx, r.db, userId, id)
+}
+func (r *TodoItemPostgres) Purge(ctx context.Context, before time.Time) (int, error) {
+	return purge(ctx, r.db, before)
+}
+func (r *TodoItemPostgres) GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error) {
+	return selectRevisions(ctx, r.db, userId, id)
 }
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	todoListSber "todo-list-sber"
+)
+
+// todoItemColumns are the columns scanned into todoListSber.TodoItem.
+const todoItemColumns = "id, title, description, date, is_done, priority, list_id, parent_id, recurrence, timezone, next_id, deleted_at, version"
+
+// itemQueryDialect holds what differs between the Postgres and SQLite renderings of a TodoItemQuery.
+type itemQueryDialect struct {
+	// dayExpr yields the wall-clock day of todo_items.date as "YYYY-MM-DD".
+	dayExpr string
+	// likeOp is the case-insensitive LIKE operator.
+	likeOp string
+	// forUpdate locks the rows read by a SELECT until the end of the transaction.
+	forUpdate string
+}
+
+var (
+	postgresDialect = itemQueryDialect{dayExpr: "date::date", likeOp: "ILIKE", forUpdate: " FOR UPDATE"}
+	// The SQLite driver stores timestamps as "2006-01-02 15:04:05.999999999-07:00" text, so the
+	// first ten characters are the wall-clock day. LIKE only folds the case of ASCII letters.
+	// Transactions are opened with _txlock=immediate and hold the database lock already.
+	sqliteDialect = itemQueryDialect{dayExpr: "substr(date, 1, 10)", likeOp: "LIKE"}
+)
+
+// dialectOf returns the dialect of the driver db was opened with.
+func dialectOf(db querier) itemQueryDialect {
+	if db.DriverName() == DriverSQLite {
+		return sqliteDialect
+	}
+	return postgresDialect
+}
+
+// selectPage runs query against todo_items of userId and loads the tags of the page.
+func (d itemQueryDialect) selectPage(ctx context.Context, db dbConn, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error) {
+	where, args := d.where(userId, query)
+
+	var page todoListSber.TodoItemPage
+	if !query.SkipTotal {
+		if err := db.GetContext(ctx, &page.Total, db.Rebind("SELECT COUNT(*) FROM todo_items"+where), args...); err != nil {
+			return page, err
+		}
+	}
+
+	selectQuery := "SELECT " + todoItemColumns + " FROM todo_items" + where + orderBy(query)
+	if query.Limit > 0 {
+		selectQuery += " LIMIT ? OFFSET ?"
+		args = append(args, query.Limit, query.Offset)
+	}
+	if err := db.SelectContext(ctx, &page.Items, db.Rebind(selectQuery), args...); err != nil {
+		return page, err
+	}
+	if err := loadTags(ctx, db, page.Items); err != nil {
+		return page, err
+	}
+	return page, nil
+}
+
+func (d itemQueryDialect) where(userId int, query todoListSber.TodoItemQuery) (string, []interface{}) {
+	where := " WHERE user_id = ? AND deleted_at IS NULL"
+	args := []interface{}{userId}
+
+	if query.IsDone != nil {
+		where += " AND is_done = ?"
+		args = append(args, *query.IsDone)
+	}
+	if query.From != nil {
+		where += " AND " + d.dayExpr + " >= ?"
+		args = append(args, query.From.Format("2006-01-02"))
+	}
+	if query.To != nil {
+		where += " AND " + d.dayExpr + " <= ?"
+		args = append(args, query.To.Format("2006-01-02"))
+	}
+	if query.Text != "" {
+		where += " AND (title " + d.likeOp + " ? ESCAPE '\\' OR description " + d.likeOp + " ? ESCAPE '\\')"
+		pattern := "%" + escapeLike(query.Text) + "%"
+		args = append(args, pattern, pattern)
+	}
+	if query.ListId != nil {
+		where += " AND list_id = ?"
+		args = append(args, *query.ListId)
+	}
+	if query.Ready {
+		where += " AND is_done = ? AND NOT EXISTS (SELECT 1 FROM todo_item_blockers b JOIN todo_items bi ON bi.id = b.blocker_id" +
+			" WHERE b.item_id = todo_items.id AND bi.is_done = ? AND bi.deleted_at IS NULL)"
+		args = append(args, false, false)
+	}
+	if query.After != nil {
+		// Row values compare lexicographically, which is exactly the "ORDER BY date, id" order.
+		if query.Desc {
+			where += " AND (date, id) < (?, ?)"
+		} else {
+			where += " AND (date, id) > (?, ?)"
+		}
+		args = append(args, query.After.Date, query.After.Id)
+	}
+	tagClause, tagArgs := tagFilterClause(query.Tags)
+	return where + tagClause, append(args, tagArgs...)
+}
+
+func orderBy(query todoListSber.TodoItemQuery) string {
+	var fields []string
+	switch query.Sort {
+	case todoListSber.SortByDate:
+		fields = []string{"date", "id"}
+	case todoListSber.SortByTitle:
+		fields = []string{"title", "id"}
+	case todoListSber.SortById:
+		fields = []string{"id"}
+	default:
+		fields = []string{"priority", "date", "id"}
+	}
+	dir := " ASC"
+	if query.Desc {
+		dir = " DESC"
+	}
+	return " ORDER BY " + strings.Join(fields, dir+", ") + dir
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(text string) string {
+	return likeEscaper.Replace(text)
+}
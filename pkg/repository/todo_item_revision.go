@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+type revisionRow struct {
+	todoListSber.Revision
+	Changes string `db:"changes"`
+}
+
+// selectItem reads the item id of userId with its tags inside tx, locking its row until the end of
+// tx where the dialect supports it. Items in the trash are reported as not found.
+func (d itemQueryDialect) selectItem(ctx context.Context, tx dbTx, userId, id int) (todoListSber.TodoItem, error) {
+	var item todoListSber.TodoItem
+	query := tx.Rebind("SELECT " + todoItemColumns + " FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL" + d.forUpdate)
+	err := tx.GetContext(ctx, &item, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, todoListSber.ErrTodoItemNotFound(id)
+	}
+	if err != nil {
+		return item, err
+	}
+	items := []todoListSber.TodoItem{item}
+	err = loadTags(ctx, tx, items)
+	return items[0], err
+}
+
+// recordRevision stores what changed from before to after as the next revision of the item, made
+// by actorId at now, and moves the item to its next version. A change that changed nothing is
+// neither recorded nor versioned. The row of the item must be locked by tx, so that two updates
+// cannot take the same revision number.
+func recordRevision(ctx context.Context, tx dbTx, actorId int, before, after todoListSber.TodoItem, now time.Time) error {
+	changes, err := todoListSber.DiffItems(before, after)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind("UPDATE todo_items SET version = version + 1 WHERE id = ?"), after.Id); err != nil {
+		return err
+	}
+	return insertRevision(ctx, tx, actorId, after.Id, changes, now)
+}
+
+func insertRevision(ctx context.Context, tx dbTx, actorId, itemId int, changes []todoListSber.FieldChange, now time.Time) error {
+	body, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	query := tx.Rebind(`INSERT INTO todo_item_revisions (item_id, revision, actor_id, changes, created_at)
+		SELECT ?, COALESCE(MAX(revision), 0) + 1, ?, ?, ? FROM todo_item_revisions WHERE item_id = ?`)
+	_, err = tx.ExecContext(ctx, query, itemId, actorId, string(body), now.UTC(), itemId)
+	return err
+}
+
+// changeItems runs change, a change of the items matching where made outside an update, inside tx.
+// Every item whose fields it changed gets a revision by actorId and moves to its next version, like
+// an update does. The items are read before the change, trashed ones included, and their rows are
+// locked until the end of tx where the dialect supports it.
+func changeItems(ctx context.Context, tx dbTx, actorId int, where string, args []interface{}, change func() error) error {
+	before, err := selectItems(ctx, tx, where+dialectOf(tx).forUpdate, args...)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, item := range before {
+		after, err := selectItems(ctx, tx, "id = ?", item.Id)
+		if err != nil || len(after) == 0 {
+			return err
+		}
+		if err := recordRevision(ctx, tx, actorId, item, after[0], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectItems reads the items matching where, trashed ones included, with their tags.
+func selectItems(ctx context.Context, tx dbTx, where string, args ...interface{}) ([]todoListSber.TodoItem, error) {
+	var items []todoListSber.TodoItem
+	if err := tx.SelectContext(ctx, &items, tx.Rebind("SELECT "+todoItemColumns+" FROM todo_items WHERE "+where), args...); err != nil {
+		return nil, err
+	}
+	return items, loadTags(ctx, tx, items)
+}
+
+// selectRevisions returns the revisions of the item id of userId, the latest first.
+func selectRevisions(ctx context.Context, db dbConn, userId, id int) ([]todoListSber.Revision, error) {
+	if err := checkItem(ctx, db, userId, id); err != nil {
+		return nil, err
+	}
+	var rows []revisionRow
+	query := db.Rebind("SELECT item_id, revision, actor_id, changes, created_at FROM todo_item_revisions WHERE item_id = ? ORDER BY revision DESC")
+	if err := db.SelectContext(ctx, &rows, query, id); err != nil {
+		return nil, err
+	}
+	var revisions []todoListSber.Revision
+	for _, row := range rows {
+		revision := row.Revision
+		if err := json.Unmarshal([]byte(row.Changes), &revision.Changes); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
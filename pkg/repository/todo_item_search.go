@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	todoListSber "todo-list-sber"
+	"unicode"
+)
+
+// Weights of title and description matches, the defaults ts_rank gives to the A and B labels.
+const (
+	searchTitleWeight       = 1.0
+	searchDescriptionWeight = 0.4
+)
+
+// searchSnippetWords bounds the snippet like the MaxWords option of ts_headline.
+const searchSnippetWords = 35
+
+// searchTerm is a word or a quoted phrase of a search query, lowercased, with the words of a
+// phrase joined by single spaces. An excluded term matches the items that do not contain it.
+type searchTerm struct {
+	text    string
+	exclude bool
+}
+
+// searchGroup matches when any of its terms does, and a query matches when all of its groups do:
+// like websearch_to_tsquery, OR binds tighter than the implicit AND between terms.
+type searchGroup []searchTerm
+
+// parseSearch reads text in the web search syntax: words, "quoted phrases", OR between two terms
+// and - before a word or phrase to exclude it. Punctuation other than that is ignored.
+func parseSearch(text string) []searchGroup {
+	runes := []rune(lowerText(text))
+	var groups []searchGroup
+	or := false
+	for i := 0; i < len(runes); {
+		exclude := runes[i] == '-' && (i == 0 || unicode.IsSpace(runes[i-1]))
+		if exclude {
+			i++
+		}
+		var term string
+		switch {
+		case i < len(runes) && runes[i] == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			term = strings.Join(searchWords(string(runes[i+1:end])), " ")
+			i = end + 1
+		case i < len(runes) && isSearchRune(runes[i]):
+			end := i
+			for end < len(runes) && isSearchRune(runes[end]) {
+				end++
+			}
+			term = string(runes[i:end])
+			i = end
+			if term == "or" && !exclude {
+				or = len(groups) > 0
+				continue
+			}
+		default:
+			i++
+			continue
+		}
+		if term == "" {
+			continue
+		}
+		if or {
+			groups[len(groups)-1] = append(groups[len(groups)-1], searchTerm{text: term, exclude: exclude})
+		} else {
+			groups = append(groups, searchGroup{{text: term, exclude: exclude}})
+		}
+		or = false
+	}
+	return groups
+}
+
+// includedTerms returns the terms of groups an item is ranked and highlighted by.
+func includedTerms(groups []searchGroup) []string {
+	var terms []string
+	for _, group := range groups {
+		for _, term := range group {
+			if !term.exclude {
+				terms = append(terms, term.text)
+			}
+		}
+	}
+	return terms
+}
+
+func isSearchRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !isSearchRune(r) })
+}
+
+// searchItems is the search of the memory backend, which has no full-text support. A term matches
+// the title or description that contains it case-insensitively; there is no stemming, so the
+// language of the query is ignored and a word also matches as a prefix of longer forms.
+func searchItems(items []todoListSber.TodoItem, query todoListSber.SearchQuery) []todoListSber.SearchResult {
+	groups := parseSearch(query.Text)
+	if len(groups) == 0 {
+		return nil
+	}
+	terms := includedTerms(groups)
+
+	var results []todoListSber.SearchResult
+	for _, item := range items {
+		title, description := lowerText(item.Title), lowerText(item.Description)
+		if !matchesSearch(title+"\n"+description, groups) {
+			continue
+		}
+		var rank float64
+		for _, term := range terms {
+			rank += float64(strings.Count(title, term))*searchTitleWeight + float64(strings.Count(description, term))*searchDescriptionWeight
+		}
+		results = append(results, todoListSber.SearchResult{
+			TodoItem: item,
+			Rank:     rank,
+			Snippet:  searchSnippet(item.Title+" "+item.Description, terms),
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Id < results[j].Id
+	})
+	if query.Offset >= len(results) {
+		return nil
+	}
+	results = results[query.Offset:]
+	if query.Limit > 0 && len(results) > query.Limit {
+		results = results[:query.Limit]
+	}
+	return results
+}
+
+// matchesSearch reports whether the lowercase text matches every group.
+func matchesSearch(text string, groups []searchGroup) bool {
+	for _, group := range groups {
+		matched := false
+		for _, term := range group {
+			if strings.Contains(text, term.text) != term.exclude {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// lowerText lowercases rune by rune so that rune offsets of the result match the original text.
+func lowerText(text string) string {
+	return strings.Map(unicode.ToLower, text)
+}
+
+func hasRunesAt(text, term []rune, i int) bool {
+	for j := range term {
+		if text[i+j] != term[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// searchSnippet returns up to searchSnippetWords words of text around the first match with every
+// occurrence of the terms highlighted.
+func searchSnippet(text string, terms []string) string {
+	words := strings.Fields(text)
+	start := 0
+	for i, word := range words {
+		if highlightTerms(word, terms) != word {
+			start = max(0, min(i-searchSnippetWords/4, len(words)-searchSnippetWords))
+			break
+		}
+	}
+	end := min(len(words), start+searchSnippetWords)
+	return highlightTerms(strings.Join(words[start:end], " "), terms)
+}
+
+func highlightTerms(text string, terms []string) string {
+	runeTerms := make([][]rune, len(terms))
+	for i, term := range terms {
+		runeTerms[i] = []rune(term)
+	}
+	original := []rune(text)
+	lower := []rune(lowerText(text))
+	var b strings.Builder
+	for i := 0; i < len(original); {
+		length := 0
+		for _, term := range runeTerms {
+			if len(term) > length && i+len(term) <= len(lower) && hasRunesAt(lower, term, i) {
+				length = len(term)
+			}
+		}
+		if length == 0 {
+			b.WriteRune(original[i])
+			i++
+			continue
+		}
+		b.WriteString("<b>")
+		b.WriteString(string(original[i : i+length]))
+		b.WriteString("</b>")
+		i += length
+	}
+	return b.String()
+}
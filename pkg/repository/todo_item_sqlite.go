@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+type TodoItemSQLite struct {
+	db dbConn
+}
+
+func NewTodoItemSQLite(db *sqlx.DB) *TodoItemSQLite {
+	return &TodoItemSQLite{db: sqlDB{db}}
+}
+func (r *TodoItemSQLite) Create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error) {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback()
+
+	createTodoItemQuery := "INSERT INTO todo_items (user_id, title, description, date, is_done, priority, list_id, parent_id, recurrence, timezone) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	res, err := tx.ExecContext(ctx, createTodoItemQuery, userId, item.Title, item.Description, item.Date, item.IsDone, item.Priority, item.ListId, item.ParentId, item.Recurrence, item.Timezone)
+	if err != nil {
+		return -1, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+	if err := setItemTags(ctx, tx, userId, int(id), item.Tags); err != nil {
+		return -1, err
+	}
+	return int(id), tx.Commit()
+}
+func (r *TodoItemSQLite) GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error) {
+	return sqliteDialect.selectPage(ctx, r.db, userId, query)
+}
+func (r *TodoItemSQLite) Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error) {
+	// There is no full-text index in SQLite: every term is matched with LIKE and the rank counts its
+	// occurrences, like the memory search does in Go. Snippets are built in Go for the returned page.
+	groups := parseSearch(query.Text)
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	const title, description = "unicode_lower(title)", "unicode_lower(coalesce(description, ''))"
+
+	rank := "0"
+	var args []interface{}
+	for _, term := range includedTerms(groups) {
+		rank += fmt.Sprintf(" + %[3]g * ((length(%[1]s) - length(replace(%[1]s, ?, ''))) / length(?)) + %[4]g * ((length(%[2]s) - length(replace(%[2]s, ?, ''))) / length(?))",
+			title, description, searchTitleWeight, searchDescriptionWeight)
+		args = append(args, term, term, term, term)
+	}
+
+	where := " WHERE user_id = ? AND deleted_at IS NULL"
+	args = append(args, userId)
+	for _, group := range groups {
+		conditions := make([]string, len(group))
+		for i, term := range group {
+			conditions[i] = "(" + title + " LIKE ? ESCAPE '\\' OR " + description + " LIKE ? ESCAPE '\\')"
+			if term.exclude {
+				conditions[i] = "NOT " + conditions[i]
+			}
+			pattern := "%" + escapeLike(term.text) + "%"
+			args = append(args, pattern, pattern)
+		}
+		where += " AND (" + strings.Join(conditions, " OR ") + ")"
+	}
+
+	searchQuery := "SELECT " + todoItemColumns + ", " + rank + " AS rank FROM todo_items" + where + " ORDER BY rank DESC, id"
+	if query.Limit > 0 {
+		searchQuery += " LIMIT ? OFFSET ?"
+		args = append(args, query.Limit, query.Offset)
+	} else if query.Offset > 0 {
+		searchQuery += " LIMIT -1 OFFSET ?"
+		args = append(args, query.Offset)
+	}
+	var results []todoListSber.SearchResult
+	if err := r.db.SelectContext(ctx, &results, searchQuery, args...); err != nil {
+		return nil, err
+	}
+
+	items := make([]todoListSber.TodoItem, len(results))
+	for i := range results {
+		items[i] = results[i].TodoItem
+	}
+	if err := loadTags(ctx, r.db, items); err != nil {
+		return nil, err
+	}
+	terms := includedTerms(groups)
+	for i := range results {
+		results[i].TodoItem = items[i]
+		results[i].Snippet = searchSnippet(items[i].Title+" "+items[i].Description, terms)
+	}
+	return results, nil
+}
+func (r *TodoItemSQLite) GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error) {
+	var todoItem todoListSber.TodoItem
+	query := "SELECT " + todoItemColumns + " FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL"
+	err := r.db.GetContext(ctx, &todoItem, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoItem, todoListSber.ErrTodoItemNotFound(id)
+	}
+	if err != nil {
+		return todoItem, err
+	}
+	items := []todoListSber.TodoItem{todoItem}
+	err = loadTags(ctx, r.db, items)
+	return items[0], err
+}
+func (r *TodoItemSQLite) Delete(ctx context.Context, userId, id int) error {
+	return trashSubtree(ctx, r.db, subtreeQuery, []interface{}{id, userId}, time.Now(), todoListSber.ErrTodoItemNotFound(id))
+}
+func (r *TodoItemSQLite) Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	return sqliteDialect.update(ctx, r.db, userId, id, input)
+}
+func (r *TodoItemSQLite) GetSubtree(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error) {
+	return sqliteDialect.selectSubtree(ctx, r.db, userId, id)
+}
+func (r *TodoItemSQLite) Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error {
+	return sqliteDialect.move(ctx, r.db, userId, id, input)
+}
+func (r *TodoItemSQLite) GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error) {
+	return selectTrash(ctx, r.db, userId, limit, offset)
+}
+func (r *TodoItemSQLite) Restore(ctx context.Context, userId, id int) error {
+	return restore(ctx, r.db, userId, id)
+}
+func (r *TodoItemSQLite) Purge(ctx context.Context, before time.Time) (int, error) {
+	return purge(ctx, r.db, before)
+}
+func (r *TodoItemSQLite) GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error) {
+	return selectRevisions(ctx, r.db, userId, id)
+}
@@ -0,0 +1,787 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	_ "github.com/mattn/go-sqlite3"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/migrate"
+)
+
+// newTestRepositories returns every repository implementation that can run without external services.
+func newTestRepositories(t *testing.T) map[string]*Repository {
+	db, err := NewSQLiteDB(Config{Path: filepath.Join(t.TempDir(), "todo.db")})
+	if err != nil {
+		t.Fatalf("error opening sqlite db: %s", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migrate.New(db)
+	if err != nil {
+		t.Fatalf("error loading migrations: %s", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("error applying migrations: %s", err)
+	}
+
+	return map[string]*Repository{
+		"Memory": NewMemoryRepository(),
+		"SQLite": NewRepository(db),
+	}
+}
+
+func createTestUser(t *testing.T, repos *Repository, username string) int {
+	id, err := repos.Authorization.CreateUser(context.Background(), todoListSber.User{Username: username, PasswordHash: "hash"})
+	if err != nil {
+		t.Fatalf("error creating user: %s", err)
+	}
+	return id
+}
+
+func TestTodoItemCRUD(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			testTodoItemCRUD(t, repos)
+		})
+	}
+}
+
+func testTodoItemCRUD(t *testing.T, repos *Repository) {
+	ctx := context.Background()
+	userId := createTestUser(t, repos, "alice")
+	repo := repos.TodoItem
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	id, err := repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Task 1", Description: "Description 1", Date: date, Recurrence: "FREQ=DAILY"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if id != 1 {
+		t.Errorf("expected id 1; got %d", id)
+	}
+
+	title := "Updated Task"
+	isDone := true
+	timezone := "Europe/Moscow"
+	if err := repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{Title: &title, IsDone: &isDone, Timezone: &timezone}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item, err := repo.GetById(ctx, userId, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := todoListSber.TodoItem{Id: 1, Title: "Updated Task", Description: "Description 1", Date: date, IsDone: true, Recurrence: "FREQ=DAILY", Timezone: "Europe/Moscow", Version: 2}
+	if !reflect.DeepEqual(item, expected) {
+		t.Errorf("expected %+v; got %+v", expected, item)
+	}
+
+	stale := 1
+	var preconditionFailed *todoListSber.PreconditionFailedError
+	if err := repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{Title: &title, Version: &stale}); !errors.As(err, &preconditionFailed) {
+		t.Errorf("expected PreconditionFailedError for a stale version; got %v", err)
+	}
+	current := 2
+	if err := repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{Tags: &[]string{"work"}, Version: &current}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err := repo.GetById(ctx, userId, id); err != nil || item.Version != 3 {
+		t.Errorf("expected a tags only update to bump the version to 3; got %+v, %v", item, err)
+	}
+
+	if err := repo.Delete(ctx, userId, id); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var notFound *todoListSber.NotFoundError
+	if _, err := repo.GetById(ctx, userId, id); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError from GetById; got %v", err)
+	}
+	if err := repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{Title: &title}); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError from Update; got %v", err)
+	}
+	if err := repo.Delete(ctx, userId, id); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError from Delete; got %v", err)
+	}
+}
+
+func TestTodoItemGetByStatus(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			testTodoItemGetByStatus(t, repos)
+		})
+	}
+}
+
+func testTodoItemGetByStatus(t *testing.T, repos *Repository) {
+	ctx := context.Background()
+	userId := createTestUser(t, repos, "alice")
+	repo := repos.TodoItem
+	june8 := time.Date(2024, time.June, 8, 0, 0, 0, 0, time.UTC)
+	items := []todoListSber.TodoItem{
+		{Title: "late", Date: time.Date(2024, time.June, 8, 18, 0, 0, 0, time.UTC), IsDone: true},
+		{Title: "early", Date: time.Date(2024, time.June, 8, 9, 0, 0, 0, time.UTC), IsDone: true},
+		{Title: "other day", Date: time.Date(2024, time.June, 9, 9, 0, 0, 0, time.UTC), IsDone: true},
+		{Title: "undone", Date: time.Date(2024, time.June, 8, 12, 0, 0, 0, time.UTC), IsDone: false},
+		{Title: "undone in msk", Date: time.Date(2024, time.June, 8, 1, 0, 0, 0, time.FixedZone("MSK", 3*60*60)), IsDone: false},
+	}
+	for _, item := range items {
+		if _, err := repo.Create(ctx, userId, item); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		isDone   bool
+		date     *time.Time
+		limit    int
+		offset   int
+		expected []string
+	}{
+		{name: "Done By Date", isDone: true, date: &june8, limit: 10, offset: 0, expected: []string{"early", "late"}},
+		{name: "Done Without Date", isDone: true, limit: 10, offset: 0, expected: []string{"early", "late", "other day"}},
+		{name: "Limit", isDone: true, limit: 1, offset: 0, expected: []string{"early"}},
+		{name: "Offset", isDone: true, limit: 10, offset: 2, expected: []string{"other day"}},
+		{name: "Offset Past End", isDone: true, limit: 10, offset: 5, expected: nil},
+		{name: "Undone By Date", isDone: false, date: &june8, limit: 10, offset: 0, expected: []string{"undone in msk", "undone"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query := todoListSber.TodoItemQuery{IsDone: &test.isDone, From: test.date, To: test.date, Limit: test.limit, Offset: test.offset}
+			page, err := repo.GetAll(ctx, userId, query)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := page.Items
+			if len(got) != len(test.expected) {
+				t.Fatalf("expected %d items; got %d", len(test.expected), len(got))
+			}
+			for i, item := range got {
+				if item.Title != test.expected[i] {
+					t.Errorf("expected item %d to be %q; got %q", i, test.expected[i], item.Title)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoItemConcurrentCreate(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			testTodoItemConcurrentCreate(t, repos)
+		})
+	}
+}
+
+func testTodoItemConcurrentCreate(t *testing.T, repos *Repository) {
+	ctx := context.Background()
+	userId := createTestUser(t, repos, "alice")
+	repo := repos.TodoItem
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Task", Date: time.Now()})
+		}()
+	}
+	wg.Wait()
+
+	page, err := repo.GetAll(ctx, userId, todoListSber.TodoItemQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page.Items) != 50 || page.Total != 50 {
+		t.Errorf("expected 50 items; got %d of %d", len(page.Items), page.Total)
+	}
+}
+
+func TestTodoItemOwnership(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			alice := createTestUser(t, repos, "alice")
+			bob := createTestUser(t, repos, "bob")
+			repo := repos.TodoItem
+
+			id, err := repo.Create(ctx, alice, todoListSber.TodoItem{Title: "Alice's task", Date: time.Now()})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var notFound *todoListSber.NotFoundError
+			if _, err := repo.GetById(ctx, bob, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from GetById; got %v", err)
+			}
+			title := "Bob was here"
+			if err := repo.Update(ctx, bob, id, todoListSber.UpdateItemInput{Title: &title}); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Update; got %v", err)
+			}
+			if err := repo.Delete(ctx, bob, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Delete; got %v", err)
+			}
+			page, err := repo.GetAll(ctx, bob, todoListSber.TodoItemQuery{Limit: 10})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(page.Items) != 0 || page.Total != 0 {
+				t.Errorf("expected bob to see no items; got %d of %d", len(page.Items), page.Total)
+			}
+
+			item, err := repo.GetById(ctx, alice, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item.Title != "Alice's task" {
+				t.Errorf("expected alice's item to be unchanged; got %q", item.Title)
+			}
+		})
+	}
+}
+
+func TestTodoItemPriorityOrder(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 8, 9, 0, 0, 0, time.UTC)
+
+			items := []todoListSber.TodoItem{
+				{Title: "low early", Date: date, Priority: 4},
+				{Title: "urgent late", Date: date.Add(2 * time.Hour), Priority: 1},
+				{Title: "urgent early", Date: date.Add(time.Hour), Priority: 1},
+				{Title: "medium", Date: date, Priority: 2},
+			}
+			for _, item := range items {
+				if _, err := repo.Create(ctx, userId, item); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			priority := 3
+			if err := repo.Update(ctx, userId, 1, todoListSber.UpdateItemInput{Priority: &priority}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			expected := []string{"urgent early", "urgent late", "medium", "low early"}
+			page, err := repo.GetAll(ctx, userId, todoListSber.TodoItemQuery{Limit: 10})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := page.Items
+			var titles []string
+			for _, item := range got {
+				titles = append(titles, item.Title)
+			}
+			if !reflect.DeepEqual(titles, expected) {
+				t.Errorf("expected %v; got %v", expected, titles)
+			}
+			if got[3].Priority != 3 {
+				t.Errorf("expected updated priority 3; got %d", got[3].Priority)
+			}
+		})
+	}
+}
+
+func TestTodoItemQuery(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			repo := repos.TodoItem
+			day := func(d int) time.Time { return time.Date(2024, time.June, d, 12, 0, 0, 0, time.UTC) }
+
+			items := []todoListSber.TodoItem{
+				{Title: "Buy milk", Date: day(1), Priority: 2},
+				{Title: "Write report", Description: "Quarterly 100% done", Date: day(2), Priority: 1, IsDone: true},
+				{Title: "Call mom", Date: day(3), Priority: 3},
+				{Title: "Buy bread", Date: day(4), Priority: 1},
+			}
+			for _, item := range items {
+				if _, err := repo.Create(ctx, userId, item); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			undone := false
+			from, to := day(2), day(3)
+			tests := []struct {
+				name          string
+				query         todoListSber.TodoItemQuery
+				expected      []string
+				expectedTotal int
+			}{
+				{name: "Default Order", query: todoListSber.TodoItemQuery{}, expected: []string{"Write report", "Buy bread", "Buy milk", "Call mom"}, expectedTotal: 4},
+				{name: "Status", query: todoListSber.TodoItemQuery{IsDone: &undone, Sort: todoListSber.SortByDate}, expected: []string{"Buy milk", "Call mom", "Buy bread"}, expectedTotal: 3},
+				{name: "Date Range", query: todoListSber.TodoItemQuery{From: &from, To: &to}, expected: []string{"Write report", "Call mom"}, expectedTotal: 2},
+				{name: "From Only", query: todoListSber.TodoItemQuery{From: &to, Sort: todoListSber.SortByDate}, expected: []string{"Call mom", "Buy bread"}, expectedTotal: 2},
+				{name: "Text", query: todoListSber.TodoItemQuery{Text: "buy"}, expected: []string{"Buy bread", "Buy milk"}, expectedTotal: 2},
+				{name: "Text In Description", query: todoListSber.TodoItemQuery{Text: "100%"}, expected: []string{"Write report"}, expectedTotal: 1},
+				{name: "Escaped Wildcard", query: todoListSber.TodoItemQuery{Text: "_"}, expected: nil, expectedTotal: 0},
+				{name: "Title Desc", query: todoListSber.TodoItemQuery{Sort: todoListSber.SortByTitle, Desc: true}, expected: []string{"Write report", "Call mom", "Buy milk", "Buy bread"}, expectedTotal: 4},
+				{name: "Page", query: todoListSber.TodoItemQuery{Sort: todoListSber.SortById, Limit: 2, Offset: 1}, expected: []string{"Write report", "Call mom"}, expectedTotal: 4},
+				{name: "Page Past End", query: todoListSber.TodoItemQuery{Limit: 2, Offset: 10}, expected: nil, expectedTotal: 4},
+				{name: "After Cursor", query: todoListSber.TodoItemQuery{Sort: todoListSber.SortByDate, After: &todoListSber.ItemCursor{Date: day(2)}, SkipTotal: true}, expected: []string{"Write report", "Call mom", "Buy bread"}},
+				{name: "After Cursor Desc", query: todoListSber.TodoItemQuery{Sort: todoListSber.SortByDate, Desc: true, After: &todoListSber.ItemCursor{Date: day(3)}, SkipTotal: true}, expected: []string{"Write report", "Buy milk"}},
+			}
+			for _, test := range tests {
+				t.Run(test.name, func(t *testing.T) {
+					page, err := repo.GetAll(ctx, userId, test.query)
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+					var titles []string
+					for _, item := range page.Items {
+						titles = append(titles, item.Title)
+					}
+					if !reflect.DeepEqual(titles, test.expected) {
+						t.Errorf("expected %v; got %v", test.expected, titles)
+					}
+					if page.Total != test.expectedTotal {
+						t.Errorf("expected total %d; got %d", test.expectedTotal, page.Total)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestTodoItemSearch(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			otherId := createTestUser(t, repos, "bob")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+			items := []todoListSber.TodoItem{
+				{Title: "Купить молоко", Description: "И хлеб", Date: date},
+				{Title: "Call mom", Description: "Ask about the milk recipe", Date: date},
+				{Title: "Milk the cow", Description: "Fresh milk for breakfast", Date: date, Tags: []string{"farm"}},
+			}
+			for _, item := range items {
+				if _, err := repo.Create(ctx, userId, item); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			if _, err := repo.Create(ctx, otherId, todoListSber.TodoItem{Title: "Milk", Date: date}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			results, err := repo.Search(ctx, userId, todoListSber.SearchQuery{Text: "milk"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var titles []string
+			for _, result := range results {
+				titles = append(titles, result.Title)
+			}
+			if expected := []string{"Milk the cow", "Call mom"}; !reflect.DeepEqual(titles, expected) {
+				t.Fatalf("expected %v; got %v", expected, titles)
+			}
+			if results[0].Rank <= results[1].Rank {
+				t.Errorf("expected title match to rank higher; got %v and %v", results[0].Rank, results[1].Rank)
+			}
+			if expected := "<b>Milk</b> the cow Fresh <b>milk</b> for breakfast"; results[0].Snippet != expected {
+				t.Errorf("expected snippet %q; got %q", expected, results[0].Snippet)
+			}
+			if !reflect.DeepEqual(results[0].Tags, []string{"farm"}) {
+				t.Errorf("expected tags to be loaded; got %v", results[0].Tags)
+			}
+
+			results, err = repo.Search(ctx, userId, todoListSber.SearchQuery{Text: "МОЛОКО хлеб"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 1 || results[0].Snippet != "Купить <b>молоко</b> И <b>хлеб</b>" {
+				t.Errorf("expected the russian item; got %+v", results)
+			}
+
+			results, err = repo.Search(ctx, userId, todoListSber.SearchQuery{Text: "milk", Limit: 1, Offset: 1})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(results) != 1 || results[0].Title != "Call mom" {
+				t.Errorf("expected the second match; got %+v", results)
+			}
+
+			queries := map[string][]string{
+				"milk -cow":             {"Call mom"},
+				"хлеб or cow":           {"Milk the cow", "Купить молоко"},
+				`"milk recipe"`:         {"Call mom"},
+				`"recipe milk"`:         nil,
+				`-"fresh milk" молоко`:  {"Купить молоко"},
+				"mom or cow -breakfast": {"Call mom"},
+			}
+			for text, expected := range queries {
+				results, err := repo.Search(ctx, userId, todoListSber.SearchQuery{Text: text})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				var titles []string
+				for _, result := range results {
+					titles = append(titles, result.Title)
+				}
+				if !reflect.DeepEqual(titles, expected) {
+					t.Errorf("expected %v for %q; got %v", expected, text, titles)
+				}
+			}
+		})
+	}
+}
+
+func TestTodoItemSubtree(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			alice := createTestUser(t, repos, "alice")
+			bob := createTestUser(t, repos, "bob")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			create := func(title string, parentId *int, days int) int {
+				id, err := repo.Create(ctx, alice, todoListSber.TodoItem{Title: title, Date: date.AddDate(0, 0, days), ParentId: parentId})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return id
+			}
+			root := create("Root", nil, 5)
+			second := create("Second", &root, 2)
+			first := create("First", &root, 1)
+			nested := create("Nested", &second, 0)
+			other := create("Other", nil, 0)
+
+			titles := func(userId, id int) []string {
+				items, err := repo.GetSubtree(ctx, userId, id)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				var titles []string
+				for _, item := range items {
+					titles = append(titles, item.Title)
+				}
+				return titles
+			}
+			if expected := []string{"Root", "Nested", "First", "Second"}; !reflect.DeepEqual(titles(alice, root), expected) {
+				t.Errorf("expected subtree %v; got %v", expected, titles(alice, root))
+			}
+			item, err := repo.GetById(ctx, alice, nested)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item.ParentId == nil || *item.ParentId != second {
+				t.Errorf("expected parent %d; got %v", second, item.ParentId)
+			}
+
+			if err := repo.Move(ctx, alice, root, todoListSber.MoveItemInput{ParentId: &nested}); !errors.Is(err, todoListSber.ErrItemCycle) {
+				t.Errorf("expected ErrItemCycle moving an item under its subtask; got %v", err)
+			}
+			if err := repo.Move(ctx, alice, second, todoListSber.MoveItemInput{ParentId: &second}); !errors.Is(err, todoListSber.ErrItemCycle) {
+				t.Errorf("expected ErrItemCycle moving an item under itself; got %v", err)
+			}
+			var notFound *todoListSber.NotFoundError
+			if err := repo.Move(ctx, bob, second, todoListSber.MoveItemInput{ParentId: &other}); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError moving an item of another user; got %v", err)
+			}
+			if _, err := repo.GetSubtree(ctx, bob, root); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from GetSubtree; got %v", err)
+			}
+
+			if err := repo.Move(ctx, alice, second, todoListSber.MoveItemInput{ParentId: &other}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := []string{"Other", "Nested", "Second"}; !reflect.DeepEqual(titles(alice, other), expected) {
+				t.Errorf("expected the moved subtree %v; got %v", expected, titles(alice, other))
+			}
+			if err := repo.Move(ctx, alice, first, todoListSber.MoveItemInput{}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := []string{"Root"}; !reflect.DeepEqual(titles(alice, root), expected) {
+				t.Errorf("expected %v; got %v", expected, titles(alice, root))
+			}
+
+			if err := repo.Delete(ctx, alice, other); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := repo.GetById(ctx, alice, nested); !errors.As(err, &notFound) {
+				t.Errorf("expected subtasks to be deleted with their parent; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemTrash(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			alice := createTestUser(t, repos, "alice")
+			bob := createTestUser(t, repos, "bob")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			create := func(title string, parentId *int, days int) int {
+				id, err := repo.Create(ctx, alice, todoListSber.TodoItem{Title: title, Date: date.AddDate(0, 0, days), ParentId: parentId})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return id
+			}
+			root := create("Root", nil, 0)
+			child := create("Child", &root, 1)
+			create("Sibling", &root, 2)
+			nested := create("Nested", &child, 3)
+
+			titles := func(items []todoListSber.TodoItem) []string {
+				var titles []string
+				for _, item := range items {
+					titles = append(titles, item.Title)
+				}
+				return titles
+			}
+			trash := func() []string {
+				items, err := repo.GetTrash(ctx, alice, 10, 0)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				for _, item := range items {
+					if item.DeletedAt == nil {
+						t.Errorf("expected %q to have a deletion time", item.Title)
+					}
+				}
+				return titles(items)
+			}
+
+			// The subtask deleted on its own stays in the trash when its parent is restored.
+			if err := repo.Delete(ctx, alice, nested); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			time.Sleep(time.Millisecond)
+			if err := repo.Delete(ctx, alice, root); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var notFound *todoListSber.NotFoundError
+			if _, err := repo.GetById(ctx, alice, child); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for a trashed item; got %v", err)
+			}
+			if err := repo.Delete(ctx, alice, root); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError deleting a trashed item; got %v", err)
+			}
+			page, err := repo.GetAll(ctx, alice, todoListSber.TodoItemQuery{})
+			if err != nil || len(page.Items) != 0 || page.Total != 0 {
+				t.Errorf("expected no items outside the trash; got %v, %v", page, err)
+			}
+			if expected := []string{"Root", "Child", "Sibling", "Nested"}; !reflect.DeepEqual(trash(), expected) {
+				t.Errorf("expected trash %v; got %v", expected, trash())
+			}
+
+			if err := repo.Restore(ctx, alice, child); !errors.Is(err, todoListSber.ErrParentInTrash) {
+				t.Errorf("expected ErrParentInTrash; got %v", err)
+			}
+			if err := repo.Restore(ctx, bob, root); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError restoring an item of another user; got %v", err)
+			}
+			if err := repo.Restore(ctx, alice, root); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repo.Restore(ctx, alice, root); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError restoring an item outside the trash; got %v", err)
+			}
+			subtree, err := repo.GetSubtree(ctx, alice, root)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if expected := []string{"Root", "Child", "Sibling"}; !reflect.DeepEqual(titles(subtree), expected) {
+				t.Errorf("expected restored subtree %v; got %v", expected, titles(subtree))
+			}
+			if expected := []string{"Nested"}; !reflect.DeepEqual(trash(), expected) {
+				t.Errorf("expected trash %v; got %v", expected, trash())
+			}
+
+			if purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || purged != 0 {
+				t.Errorf("expected nothing to be purged within the retention; got %d, %v", purged, err)
+			}
+			if purged, err := repo.Purge(ctx, time.Now().Add(time.Hour)); err != nil || purged != 1 {
+				t.Errorf("expected the trashed item to be purged; got %d, %v", purged, err)
+			}
+			if err := repo.Restore(ctx, alice, nested); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError restoring a purged item; got %v", err)
+			}
+			if len(trash()) != 0 {
+				t.Errorf("expected an empty trash; got %v", trash())
+			}
+		})
+	}
+}
+
+func TestTodoItemHistory(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			alice := createTestUser(t, repos, "alice")
+			bob := createTestUser(t, repos, "bob")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			id, err := repo.Create(ctx, alice, todoListSber.TodoItem{Title: "Report", Date: date, Priority: 4, Tags: []string{"work"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			later := date.AddDate(0, 0, 1)
+			isDone := true
+			for _, input := range []todoListSber.UpdateItemInput{
+				{Date: &later, IsDone: &isDone},
+				{Tags: &[]string{"work"}},
+				{Tags: &[]string{"work", "urgent"}},
+			} {
+				if err := repo.Update(ctx, alice, id, input); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			history, err := repo.GetHistory(ctx, alice, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(history) != 2 {
+				t.Fatalf("expected the update without changes to be left out; got %+v", history)
+			}
+			if item, err := repo.GetById(ctx, alice, id); err != nil || item.Version != 3 {
+				t.Errorf("expected the update without changes to keep the version at 3; got %+v, %v", item, err)
+			}
+			if history[0].Revision != 2 || history[0].ActorId != alice || history[0].CreatedAt.IsZero() {
+				t.Errorf("expected the latest revision first; got %+v", history[0])
+			}
+			expected := []todoListSber.FieldChange{
+				{Field: "is_done", Old: []byte(`false`), New: []byte(`true`)},
+				{Field: "date", Old: []byte(`"2024-06-05T20:00:00Z"`), New: []byte(`"2024-06-06T20:00:00Z"`)},
+			}
+			if history[1].Revision != 1 || !reflect.DeepEqual(history[1].Changes, expected) {
+				t.Errorf("expected changes %s; got %+v", expected, history[1])
+			}
+			if expected := []byte(`["urgent","work"]`); !reflect.DeepEqual([]byte(history[0].Changes[0].New), expected) {
+				t.Errorf("expected tags %s; got %s", expected, history[0].Changes[0].New)
+			}
+
+			var notFound *todoListSber.NotFoundError
+			if _, err := repo.GetHistory(ctx, bob, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for an item of another user; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemHistoryOutsideUpdates(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			listId, err := repos.TodoList.Create(ctx, userId, todoListSber.TodoList{Title: "Work"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			parent, err := repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Parent", Date: date})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			id, err := repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Task", Date: date, ListId: &listId})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			tagId, err := repos.Tag.Create(ctx, userId, todoListSber.Tag{Name: "work"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for _, change := range []func() error{
+				func() error { return repo.Move(ctx, userId, id, todoListSber.MoveItemInput{ParentId: &parent}) },
+				func() error { return repos.Tag.Attach(ctx, userId, id, tagId) },
+				func() error { return repos.Tag.Update(ctx, userId, tagId, todoListSber.Tag{Name: "office"}) },
+				func() error { return repos.TodoList.Delete(ctx, userId, listId, false) },
+			} {
+				if err := change(); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			history, err := repo.GetHistory(ctx, userId, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var fields []string
+			for i := len(history) - 1; i >= 0; i-- {
+				for _, change := range history[i].Changes {
+					fields = append(fields, change.Field)
+				}
+			}
+			if expected := []string{"parent_id", "tags", "tags", "list_id"}; !reflect.DeepEqual(fields, expected) {
+				t.Errorf("expected revisions of %v; got %v", expected, fields)
+			}
+			item, err := repo.GetById(ctx, userId, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item.Version != 5 {
+				t.Errorf("expected version 5 after four changes; got %d", item.Version)
+			}
+			stale := 4
+			if err := repo.Move(ctx, userId, id, todoListSber.MoveItemInput{Version: &stale}); err == nil {
+				t.Error("expected a move at a stale version to fail")
+			}
+		})
+	}
+}
+
+func TestTodoItemNextOccurrence(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			repo := repos.TodoItem
+			date := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+			id, err := repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Report", Date: date, Recurrence: "FREQ=WEEKLY"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			nextId, err := repo.Create(ctx, userId, todoListSber.TodoItem{Title: "Report", Date: date.AddDate(0, 0, 7), Recurrence: "FREQ=WEEKLY"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{NextId: &nextId}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			item, err := repo.GetById(ctx, userId, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item.NextId == nil || *item.NextId != nextId || item.Version != 1 {
+				t.Errorf("expected the item linked to %d at version 1; got %+v", nextId, item)
+			}
+			if history, err := repo.GetHistory(ctx, userId, id); err != nil || len(history) != 0 {
+				t.Errorf("expected the link left out of the history; got %+v, %v", history, err)
+			}
+
+			// Purging the next occurrence unlinks it.
+			if err := repo.Delete(ctx, userId, nextId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := repo.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item, err = repo.GetById(ctx, userId, id); err != nil || item.NextId != nil {
+				t.Errorf("expected the link cleared; got %+v, %v", item, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// listSubtreeQuery collects the ids of the items of a list and of their subtasks at any depth,
+// leaving out the trash, like subtreeQuery.
+const listSubtreeQuery = `WITH RECURSIVE subtree (id) AS (
+		SELECT id FROM todo_items WHERE list_id = ? AND user_id = ? AND deleted_at IS NULL
+		UNION
+		SELECT i.id FROM todo_items i JOIN subtree s ON i.parent_id = s.id WHERE i.deleted_at IS NULL
+	)`
+
+// trashSubtree moves the items collected by subtree, a query defining the "subtree" CTE, to the
+// trash at now. notFound is reported when there is nothing to move.
+func trashSubtree(ctx context.Context, q sqlx.ExtContext, subtree string, args []interface{}, now time.Time, notFound error) error {
+	query := q.Rebind(subtree + " UPDATE todo_items SET deleted_at = ? WHERE id IN (SELECT id FROM subtree)")
+	res, err := q.ExecContext(ctx, query, append(args, now.UTC())...)
+	if err != nil {
+		return err
+	}
+	if notFound == nil {
+		return nil
+	}
+	return checkAffected(res, notFound)
+}
+
+// selectTrash returns a page of the trashed items of userId, the most recently deleted first.
+func selectTrash(ctx context.Context, db dbConn, userId, limit, offset int) ([]todoListSber.TodoItem, error) {
+	var items []todoListSber.TodoItem
+	query := db.Rebind("SELECT " + todoItemColumns + " FROM todo_items WHERE user_id = ? AND deleted_at IS NOT NULL ORDER BY deleted_at DESC, id LIMIT ? OFFSET ?")
+	if err := db.SelectContext(ctx, &items, query, userId, limit, offset); err != nil {
+		return nil, err
+	}
+	if err := loadTags(ctx, db, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// restore takes the trashed item id out of the trash together with the subtasks that were deleted
+// with it. Subtasks deleted on their own before stay in the trash.
+func restore(ctx context.Context, db dbConn, userId, id int) error {
+	tx, err := db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var parentId *int
+	err = tx.GetContext(ctx, &parentId, tx.Rebind("SELECT parent_id FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL"), id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoListSber.ErrTodoItemNotFound(id)
+	}
+	if err != nil {
+		return err
+	}
+	if parentId != nil {
+		var trashed bool
+		if err := tx.GetContext(ctx, &trashed, tx.Rebind("SELECT deleted_at IS NOT NULL FROM todo_items WHERE id = ?"), *parentId); err != nil {
+			return err
+		}
+		if trashed {
+			return todoListSber.ErrParentInTrash
+		}
+	}
+	query := `WITH RECURSIVE trashed (id, deleted_at) AS (
+			SELECT id, deleted_at FROM todo_items WHERE id = ?
+			UNION
+			SELECT i.id, i.deleted_at FROM todo_items i JOIN trashed t ON i.parent_id = t.id AND i.deleted_at = t.deleted_at
+		)
+		UPDATE todo_items SET deleted_at = NULL WHERE id IN (SELECT id FROM trashed)`
+	if _, err := tx.ExecContext(ctx, tx.Rebind(query), id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// purge deletes the items that were moved to the trash before before, of all users.
+func purge(ctx context.Context, db dbConn, before time.Time) (int, error) {
+	res, err := db.ExecContext(ctx, db.Rebind("DELETE FROM todo_items WHERE deleted_at < ?"), before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
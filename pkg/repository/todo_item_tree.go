@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	todoListSber "todo-list-sber"
+)
+
+// subtreeQuery collects the ids of an item and of its subtasks at any depth, leaving out the
+// trash. UNION rather than UNION ALL stops the recursion should the rows ever form a cycle.
+const subtreeQuery = `WITH RECURSIVE subtree (id) AS (
+		SELECT id FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+		UNION
+		SELECT i.id FROM todo_items i JOIN subtree s ON i.parent_id = s.id WHERE i.deleted_at IS NULL
+	)`
+
+// ancestorsQuery collects the ids of an item and of the items above it.
+const ancestorsQuery = `WITH RECURSIVE ancestors (id, parent_id) AS (
+		SELECT id, parent_id FROM todo_items WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+		UNION
+		SELECT i.id, i.parent_id FROM todo_items i JOIN ancestors a ON i.id = a.parent_id
+	)
+	SELECT id FROM ancestors`
+
+// selectSubtree returns the item id of userId followed by its subtasks at any depth ordered by
+// date and id, with their tags.
+func (d itemQueryDialect) selectSubtree(ctx context.Context, db dbConn, userId, id int) ([]todoListSber.TodoItem, error) {
+	query := subtreeQuery + " SELECT " + todoItemColumns + " FROM todo_items WHERE id IN (SELECT id FROM subtree)" +
+		" ORDER BY CASE WHEN id = ? THEN 0 ELSE 1 END, date, id"
+	var items []todoListSber.TodoItem
+	if err := db.SelectContext(ctx, &items, db.Rebind(query), id, userId, id); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, todoListSber.ErrTodoItemNotFound(id)
+	}
+	if err := loadTags(ctx, db, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// move puts the item id under input.ParentId, or at the top level when it is nil.
+func (d itemQueryDialect) move(ctx context.Context, db dbConn, userId, id int, input todoListSber.MoveItemInput) error {
+	tx, err := db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := d.lockUser(ctx, tx, userId, todoListSber.ErrTodoItemNotFound(id)); err != nil {
+		return err
+	}
+	item, err := d.selectItem(ctx, tx, userId, id)
+	if err != nil {
+		return err
+	}
+	if input.Version != nil && *input.Version != item.Version {
+		return todoListSber.ErrVersionMismatch(id, item.Version)
+	}
+	parentId := input.ParentId
+	if parentId != nil {
+		var ancestors []int
+		if err := tx.SelectContext(ctx, &ancestors, tx.Rebind(ancestorsQuery), *parentId, userId); err != nil {
+			return err
+		}
+		if len(ancestors) == 0 {
+			return todoListSber.ErrTodoItemNotFound(*parentId)
+		}
+		for _, ancestor := range ancestors {
+			if ancestor == id {
+				return todoListSber.ErrItemCycle
+			}
+		}
+	}
+	err = changeItems(ctx, tx, userId, "id = ?", []interface{}{id}, func() error {
+		_, err := tx.ExecContext(ctx, tx.Rebind("UPDATE todo_items SET parent_id = ? WHERE id = ?"), parentId, id)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// lockUser locks the row of userId until the end of tx. Changes that check the items of a user for
+// cycles take it first, so that two concurrent changes cannot close a cycle together. notFound is
+// reported when the user does not exist.
+func (d itemQueryDialect) lockUser(ctx context.Context, tx dbTx, userId int, notFound error) error {
+	var id int
+	err := tx.GetContext(ctx, &id, tx.Rebind("SELECT id FROM users WHERE id = ?"+d.forUpdate), userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return err
+}
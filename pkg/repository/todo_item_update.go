@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+// update sets the fields of input on the item id of userId. What changed is recorded as a revision
+// and moves the item to its next version; an update that changed nothing leaves it as it is.
+func (d itemQueryDialect) update(ctx context.Context, db dbConn, userId, id int, input todoListSber.UpdateItemInput) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if input.Title != nil {
+		setValues = append(setValues, "title=?")
+		args = append(args, *input.Title)
+	}
+	if input.Description != nil {
+		setValues = append(setValues, "description=?")
+		args = append(args, *input.Description)
+	}
+	if input.IsDone != nil {
+		setValues = append(setValues, "is_done=?")
+		args = append(args, *input.IsDone)
+	}
+	if input.Date != nil {
+		setValues = append(setValues, "date=?")
+		args = append(args, *input.Date)
+	}
+	if input.Priority != nil {
+		setValues = append(setValues, "priority=?")
+		args = append(args, *input.Priority)
+	}
+	if input.ListId != nil {
+		setValues = append(setValues, "list_id=?")
+		args = append(args, *input.ListId)
+	} else if input.ClearList {
+		setValues = append(setValues, "list_id=NULL")
+	}
+	if input.Recurrence != nil {
+		setValues = append(setValues, "recurrence=?")
+		args = append(args, *input.Recurrence)
+	}
+	if input.Timezone != nil {
+		setValues = append(setValues, "timezone=?")
+		args = append(args, *input.Timezone)
+	}
+	if input.NextId != nil {
+		setValues = append(setValues, "next_id=?")
+		args = append(args, *input.NextId)
+	}
+
+	tx, err := db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	before, err := d.selectItem(ctx, tx, userId, id)
+	if err != nil {
+		return err
+	}
+	if input.Version != nil && *input.Version != before.Version {
+		return todoListSber.ErrVersionMismatch(id, before.Version)
+	}
+	if len(setValues) > 0 {
+		query := tx.Rebind("UPDATE todo_items SET " + strings.Join(setValues, ", ") + " WHERE id = ? AND user_id = ?")
+		args = append(args, id, userId)
+		slog.Debug("update todo item", "query", query, "args", args)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	if input.Tags != nil {
+		if err := setItemTags(ctx, tx, userId, id, *input.Tags); err != nil {
+			return err
+		}
+	}
+	if input.Date != nil {
+		if err := rescheduleReminders(ctx, tx, id, *input.Date, time.Now()); err != nil {
+			return err
+		}
+	}
+	after, err := d.selectItem(ctx, tx, userId, id)
+	if err != nil {
+		return err
+	}
+	if err := recordRevision(ctx, tx, userId, before, after, time.Now()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	todoListSber "todo-list-sber"
+)
+
+// TodoListMemory keeps todo lists in process memory. It shares the item store so that
+// deleting a list can detach or delete its items, as the SQL foreign key does.
+type TodoListMemory struct {
+	*listStore
+	mu    rwLocker
+	undo  *undoLog
+	items *TodoItemMemory
+}
+
+// listStore is the data of TodoListMemory, shared with the views of its transactions.
+type listStore struct {
+	lists  map[int]todoListSber.TodoList
+	owners map[int]int
+	nextId int
+}
+
+func NewTodoListMemory(items *TodoItemMemory) *TodoListMemory {
+	return &TodoListMemory{mu: &sync.RWMutex{}, items: items, listStore: &listStore{
+		lists:  make(map[int]todoListSber.TodoList),
+		owners: make(map[int]int),
+		nextId: 1,
+	}}
+}
+func (r *TodoListMemory) Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list.Id = r.nextId
+	setEntry(r.undo, r.lists, list.Id, list)
+	setEntry(r.undo, r.owners, list.Id, userId)
+	r.nextId++
+	return list.Id, nil
+}
+func (r *TodoListMemory) GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var lists []todoListSber.TodoList
+	for _, list := range r.lists {
+		if r.owners[list.Id] != userId {
+			continue
+		}
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Id < lists[j].Id
+	})
+	return lists, nil
+}
+func (r *TodoListMemory) GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, ok := r.lists[id]
+	if !ok || r.owners[id] != userId {
+		return todoListSber.TodoList{}, todoListSber.ErrTodoListNotFound(id)
+	}
+	return list, nil
+}
+func (r *TodoListMemory) Delete(ctx context.Context, userId, id int, cascade bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.lists[id]; !ok || r.owners[id] != userId {
+		return todoListSber.ErrTodoListNotFound(id)
+	}
+	deleteEntry(r.undo, r.lists, id)
+	deleteEntry(r.undo, r.owners, id)
+	return r.items.removeList(userId, id, cascade)
+}
+func (r *TodoListMemory) Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list, ok := r.lists[id]
+	if !ok || r.owners[id] != userId {
+		return todoListSber.ErrTodoListNotFound(id)
+	}
+	if input.Title != nil {
+		list.Title = *input.Title
+	}
+	if input.Description != nil {
+		list.Description = *input.Description
+	}
+	setEntry(r.undo, r.lists, id, list)
+	return nil
+}
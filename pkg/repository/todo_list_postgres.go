@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+type TodoListPostgres struct {
+	db dbConn
+}
+
+func NewTodoListPostgres(db *sqlx.DB) *TodoListPostgres {
+	return &TodoListPostgres{db: sqlDB{db}}
+}
+func (r *TodoListPostgres) Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error) {
+	var id int
+	query := "INSERT INTO todo_lists (user_id, title, description) VALUES ($1, $2, $3) RETURNING id"
+	err := r.db.QueryRowxContext(ctx, query, userId, list.Title, list.Description).Scan(&id)
+	if err != nil {
+		return -1, err
+	}
+	return id, nil
+}
+func (r *TodoListPostgres) GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error) {
+	var lists []todoListSber.TodoList
+	query := "SELECT id, title, description FROM todo_lists WHERE user_id = $1 ORDER BY id"
+	err := r.db.SelectContext(ctx, &lists, query, userId)
+	return lists, err
+}
+func (r *TodoListPostgres) GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error) {
+	var list todoListSber.TodoList
+	query := "SELECT id, title, description FROM todo_lists WHERE id = $1 AND user_id = $2"
+	err := r.db.GetContext(ctx, &list, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return list, todoListSber.ErrTodoListNotFound(id)
+	}
+	return list, err
+}
+
+// Delete relies on "ON DELETE SET NULL" of todo_items.list_id to keep the items unless cascade is set,
+// which moves the items of the list and their subtasks to the trash.
+func (r *TodoListPostgres) Delete(ctx context.Context, userId, id int, cascade bool) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Deleting the list clears list_id of its items, which their history records.
+	err = changeItems(ctx, tx, userId, "list_id = ? AND user_id = ?", []interface{}{id, userId}, func() error {
+		if cascade {
+			if err := trashSubtree(ctx, tx, listSubtreeQuery, []interface{}{id, userId}, time.Now(), nil); err != nil {
+				return err
+			}
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM todo_lists WHERE id = $1 AND user_id = $2", id, userId)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTodoListNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TodoListPostgres) Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Title != nil {
+		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
+		args = append(args, *input.Title)
+		argId++
+	}
+	if input.Description != nil {
+		setValues = append(setValues, fmt.Sprintf("description=$%d", argId))
+		args = append(args, *input.Description)
+		argId++
+	}
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE todo_lists SET %s WHERE id = $%d AND user_id = $%d", setQuery, argId, argId+1)
+	args = append(args, id, userId)
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrTodoListNotFound(id))
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+type TodoListSQLite struct {
+	db dbConn
+}
+
+func NewTodoListSQLite(db *sqlx.DB) *TodoListSQLite {
+	return &TodoListSQLite{db: sqlDB{db}}
+}
+func (r *TodoListSQLite) Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error) {
+	query := "INSERT INTO todo_lists (user_id, title, description) VALUES (?, ?, ?)"
+	res, err := r.db.ExecContext(ctx, query, userId, list.Title, list.Description)
+	if err != nil {
+		return -1, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return -1, err
+	}
+	return int(id), nil
+}
+func (r *TodoListSQLite) GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error) {
+	var lists []todoListSber.TodoList
+	query := "SELECT id, title, description FROM todo_lists WHERE user_id = ? ORDER BY id"
+	err := r.db.SelectContext(ctx, &lists, query, userId)
+	return lists, err
+}
+func (r *TodoListSQLite) GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error) {
+	var list todoListSber.TodoList
+	query := "SELECT id, title, description FROM todo_lists WHERE id = ? AND user_id = ?"
+	err := r.db.GetContext(ctx, &list, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return list, todoListSber.ErrTodoListNotFound(id)
+	}
+	return list, err
+}
+
+// Delete relies on "ON DELETE SET NULL" of todo_items.list_id, which needs the connection
+// to be opened with _foreign_keys=on as NewSQLiteDB does. With cascade the items of the list
+// and their subtasks are moved to the trash.
+func (r *TodoListSQLite) Delete(ctx context.Context, userId, id int, cascade bool) error {
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Deleting the list clears list_id of its items, which their history records.
+	err = changeItems(ctx, tx, userId, "list_id = ? AND user_id = ?", []interface{}{id, userId}, func() error {
+		if cascade {
+			if err := trashSubtree(ctx, tx, listSubtreeQuery, []interface{}{id, userId}, time.Now(), nil); err != nil {
+				return err
+			}
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM todo_lists WHERE id = ? AND user_id = ?", id, userId)
+		if err != nil {
+			return err
+		}
+		return checkAffected(res, todoListSber.ErrTodoListNotFound(id))
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+func (r *TodoListSQLite) Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if input.Title != nil {
+		setValues = append(setValues, "title=?")
+		args = append(args, *input.Title)
+	}
+	if input.Description != nil {
+		setValues = append(setValues, "description=?")
+		args = append(args, *input.Description)
+	}
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE todo_lists SET %s WHERE id = ? AND user_id = ?", setQuery)
+	args = append(args, id, userId)
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrTodoListNotFound(id))
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestTodoListCRUD(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			otherId := createTestUser(t, repos, "bob")
+			repo := repos.TodoList
+
+			id, err := repo.Create(ctx, userId, todoListSber.TodoList{Title: "Work", Description: "Office tasks"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			title := "Job"
+			if err := repo.Update(ctx, userId, id, todoListSber.UpdateListInput{Title: &title}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			list, err := repo.GetById(ctx, userId, id)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if list.Title != "Job" || list.Description != "Office tasks" {
+				t.Errorf("unexpected list after update: %+v", list)
+			}
+
+			lists, err := repo.GetAll(ctx, otherId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(lists) != 0 {
+				t.Errorf("expected bob to see no lists; got %d", len(lists))
+			}
+			var notFound *todoListSber.NotFoundError
+			if _, err := repo.GetById(ctx, otherId, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from GetById; got %v", err)
+			}
+			if err := repo.Update(ctx, otherId, id, todoListSber.UpdateListInput{Title: &title}); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Update; got %v", err)
+			}
+			if err := repo.Delete(ctx, otherId, id, false); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError from Delete; got %v", err)
+			}
+
+			if err := repo.Delete(ctx, userId, id, false); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if _, err := repo.GetById(ctx, userId, id); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError after delete; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoListDelete(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			createList := func(title string) (int, int) {
+				listId, err := repos.TodoList.Create(ctx, userId, todoListSber.TodoList{Title: title})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				itemId, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Task", Date: date, ListId: &listId})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				page, err := repos.TodoItem.GetAll(ctx, userId, todoListSber.TodoItemQuery{ListId: &listId})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if len(page.Items) != 1 || page.Items[0].Id != itemId {
+					t.Fatalf("expected item %d in list %d; got %+v", itemId, listId, page.Items)
+				}
+				return listId, itemId
+			}
+
+			listId, itemId := createList("Keep items")
+			if err := repos.TodoList.Delete(ctx, userId, listId, false); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			item, err := repos.TodoItem.GetById(ctx, userId, itemId)
+			if err != nil {
+				t.Fatalf("expected item to survive the list; got %s", err)
+			}
+			if item.ListId != nil {
+				t.Errorf("expected item to be moved out of the list; got list %d", *item.ListId)
+			}
+
+			listId, itemId = createList("Cascade")
+			if err := repos.TodoList.Delete(ctx, userId, listId, true); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var notFound *todoListSber.NotFoundError
+			if _, err := repos.TodoItem.GetById(ctx, userId, itemId); !errors.As(err, &notFound) {
+				t.Errorf("expected item to be deleted with the list; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemClearList(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+			listId, err := repos.TodoList.Create(ctx, userId, todoListSber.TodoList{Title: "Work"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			itemId, err := repos.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Task", Date: date, ListId: &listId})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repos.TodoItem.Update(ctx, userId, itemId, todoListSber.UpdateItemInput{ClearList: true}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			item, err := repos.TodoItem.GetById(ctx, userId, itemId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if item.ListId != nil || item.Version != 2 {
+				t.Errorf("expected the item out of its list at version 2; got list %v, version %d", item.ListId, item.Version)
+			}
+
+			history, err := repos.TodoItem.GetHistory(ctx, userId, itemId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(history) != 1 || len(history[0].Changes) != 1 || history[0].Changes[0].Field != "list_id" || string(history[0].Changes[0].New) != "null" {
+				t.Fatalf("expected list_id cleared in the history; got %+v", history)
+			}
+			plan, err := todoListSber.PlanRevert(item, history, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if plan.Input == nil || plan.Input.ListId == nil || *plan.Input.ListId != listId || plan.Input.ClearList || plan.Move != nil {
+				t.Errorf("expected the revert to put the item back into list %d; got %+v", listId, plan)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+// Transactor runs fn on repositories bound to a single transaction: what fn does through them is
+// committed when it returns nil and rolled back when it returns an error. Transactions nest, so a
+// transaction begun on the repositories passed to fn is rolled back on its own.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(repos *Repository) error) error
+}
+
+// querier runs the statements of the SQL repositories, on the database or inside a transaction.
+type querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// dbTx is a transaction begun by dbConn.beginTx.
+type dbTx interface {
+	querier
+	Commit() error
+	Rollback() error
+}
+
+// dbConn is what the SQL repositories are built on: the database, which begins transactions, or a
+// transaction, which begins savepoints.
+type dbConn interface {
+	querier
+	beginTx(ctx context.Context) (dbTx, error)
+}
+
+type sqlDB struct {
+	*sqlx.DB
+}
+
+func (db sqlDB) beginTx(ctx context.Context) (dbTx, error) {
+	return db.BeginTxx(ctx, nil)
+}
+
+// txConn runs the statements of the repositories of a transaction on it.
+type txConn struct {
+	dbTx
+	// savepoints numbers the savepoints of the transaction, so that nested ones get distinct names.
+	savepoints *int
+}
+
+func (c txConn) beginTx(ctx context.Context) (dbTx, error) {
+	*c.savepoints++
+	name := fmt.Sprintf("sp_%d", *c.savepoints)
+	if _, err := c.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+	return &savepoint{dbTx: c.dbTx, ctx: ctx, name: name}, nil
+}
+
+// savepoint lets the repositories begin and commit their transactions inside a transaction that is
+// already open. Rollback after Commit does nothing, like it does for sql.Tx.
+type savepoint struct {
+	dbTx
+	ctx  context.Context
+	name string
+	done bool
+}
+
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	if _, err := s.ExecContext(s.ctx, "ROLLBACK TO SAVEPOINT "+s.name); err != nil {
+		return err
+	}
+	_, err := s.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+// sqlTransactor begins the transactions of the SQL repositories built on conn.
+type sqlTransactor struct {
+	conn dbConn
+}
+
+func (t sqlTransactor) Transaction(ctx context.Context, fn func(repos *Repository) error) error {
+	tx, err := t.conn.beginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Savepoints nested at any depth share the numbering of the outermost transaction.
+	conn := txConn{dbTx: tx, savepoints: new(int)}
+	if outer, ok := t.conn.(txConn); ok {
+		conn.savepoints = outer.savepoints
+	}
+	if err := fn(newSQLRepository(conn)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
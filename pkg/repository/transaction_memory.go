@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+)
+
+// memoryTransactor runs a transaction on views of the item and list stores that share their data.
+// The stores stay locked for the whole transaction, so that it is isolated from concurrent
+// changes, and the views record how to undo every change made through them, so that a rollback
+// only undoes the changes of the transaction. Ids taken by a rolled back transaction are not given
+// out again, as with a PostgreSQL sequence. Users, webhooks and events are not part of
+// transactions: their changes made inside one are kept.
+type memoryTransactor struct {
+	repos *Repository
+	items *TodoItemMemory
+	lists *TodoListMemory
+	// undo is nil for the transactor of the stores themselves, which locks them, and set for the
+	// transactor of a transaction, whose transactions nest.
+	undo *undoLog
+}
+
+func (t memoryTransactor) Transaction(ctx context.Context, fn func(repos *Repository) error) error {
+	if t.undo == nil {
+		t.lists.mu.Lock()
+		defer t.lists.mu.Unlock()
+		t.items.mu.Lock()
+		defer t.items.mu.Unlock()
+	}
+
+	undo := &undoLog{}
+	items := &TodoItemMemory{itemStore: t.items.itemStore, mu: noLock{}, undo: undo}
+	lists := &TodoListMemory{listStore: t.lists.listStore, mu: noLock{}, undo: undo, items: items}
+	repos := &Repository{
+		Authorization: t.repos.Authorization,
+		TodoItem:      items,
+		TodoList:      lists,
+		Tag:           NewTagMemory(items),
+		Reminder:      NewReminderMemory(items),
+		Dependency:    NewDependencyMemory(items),
+		Webhook:       t.repos.Webhook,
+		EventLog:      t.repos.EventLog,
+	}
+	repos.Transactor = memoryTransactor{repos: repos, items: items, lists: lists, undo: undo}
+
+	if err := fn(repos); err != nil {
+		undo.rollback()
+		return err
+	}
+	if t.undo != nil {
+		// The changes of a nested transaction are undone with the transaction around it.
+		t.undo.steps = append(t.undo.steps, undo.steps...)
+	}
+	return nil
+}
+
+// rwLocker guards a memory store: a sync.RWMutex, or noLock for the views of a transaction, which
+// holds the lock of the store until it ends.
+type rwLocker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+type noLock struct{}
+
+func (noLock) Lock()    {}
+func (noLock) Unlock()  {}
+func (noLock) RLock()   {}
+func (noLock) RUnlock() {}
+
+// undoLog collects how to undo the changes of a transaction, the latest last. A nil undoLog,
+// outside a transaction, collects nothing.
+type undoLog struct {
+	steps []func()
+}
+
+// rollback undoes the collected changes, the latest first.
+func (u *undoLog) rollback() {
+	for i := len(u.steps) - 1; i >= 0; i-- {
+		u.steps[i]()
+	}
+	u.steps = nil
+}
+
+// setEntry sets m[key] to value, recording in undo how to put back what was there before.
+func setEntry[K comparable, V any](undo *undoLog, m map[K]V, key K, value V) {
+	saveEntry(undo, m, key)
+	m[key] = value
+}
+
+// deleteEntry deletes key from m, recording in undo how to put it back.
+func deleteEntry[K comparable, V any](undo *undoLog, m map[K]V, key K) {
+	saveEntry(undo, m, key)
+	delete(m, key)
+}
+
+func saveEntry[K comparable, V any](undo *undoLog, m map[K]V, key K) {
+	if undo == nil {
+		return
+	}
+	old, ok := m[key]
+	undo.steps = append(undo.steps, func() {
+		if ok {
+			m[key] = old
+		} else {
+			delete(m, key)
+		}
+	})
+}
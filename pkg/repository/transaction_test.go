@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestTransaction(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			userId := createTestUser(t, repos, "alice")
+			date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+			failure := errors.New("failure")
+
+			countItems := func() int {
+				t.Helper()
+				page, err := repos.TodoItem.GetAll(ctx, userId, todoListSber.TodoItemQuery{})
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return page.Total
+			}
+
+			var committedId int
+			err := repos.Transaction(ctx, func(tx *Repository) error {
+				id, err := tx.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Committed", Date: date, Tags: []string{"work"}})
+				if err != nil {
+					return err
+				}
+				committedId = id
+				// The changes of a transaction are visible inside it.
+				_, err = tx.TodoItem.GetById(ctx, userId, id)
+				return err
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if countItems() != 1 {
+				t.Fatalf("expected the committed item")
+			}
+
+			title := "Renamed"
+			err = repos.Transaction(ctx, func(tx *Repository) error {
+				if _, err := tx.TodoItem.Create(ctx, userId, todoListSber.TodoItem{Title: "Rolled back", Date: date}); err != nil {
+					return err
+				}
+				if _, err := tx.TodoList.Create(ctx, userId, todoListSber.TodoList{Title: "Rolled back"}); err != nil {
+					return err
+				}
+				if err := tx.TodoItem.Update(ctx, userId, committedId, todoListSber.UpdateItemInput{Title: &title, Tags: &[]string{}}); err != nil {
+					return err
+				}
+				return failure
+			})
+			if !errors.Is(err, failure) {
+				t.Fatalf("expected the error of fn; got %v", err)
+			}
+			item, err := repos.TodoItem.GetById(ctx, userId, committedId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if countItems() != 1 || item.Title != "Committed" || len(item.Tags) != 1 || item.Version != 1 {
+				t.Errorf("expected the changes rolled back; got %d items and %+v", countItems(), item)
+			}
+			if lists, err := repos.TodoList.GetAll(ctx, userId); err != nil || len(lists) != 0 {
+				t.Errorf("expected the list rolled back; got %+v, %v", lists, err)
+			}
+
+			// A nested transaction is rolled back without the one around it.
+			err = repos.Transaction(ctx, func(tx *Repository) error {
+				if err := tx.TodoItem.Update(ctx, userId, committedId, todoListSber.UpdateItemInput{Title: &title}); err != nil {
+					return err
+				}
+				err := tx.Transaction(ctx, func(inner *Repository) error {
+					if err := inner.TodoItem.Delete(ctx, userId, committedId); err != nil {
+						return err
+					}
+					return failure
+				})
+				if !errors.Is(err, failure) {
+					t.Errorf("expected the error of the nested fn; got %v", err)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			item, err = repos.TodoItem.GetById(ctx, userId, committedId)
+			if err != nil {
+				t.Fatalf("expected the delete rolled back; got %s", err)
+			}
+			if item.Title != title {
+				t.Errorf("expected the update committed; got %+v", item)
+			}
+		})
+	}
+}
+
+func TestMemoryTransactionConcurrentChange(t *testing.T) {
+	ctx := context.Background()
+	repos := NewMemoryRepository()
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+	failure := errors.New("failure")
+
+	// An item created while a transaction runs waits for it and outlives its rollback.
+	created := make(chan error)
+	err := repos.Transaction(ctx, func(tx *Repository) error {
+		if _, err := tx.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Rolled back", Date: date}); err != nil {
+			return err
+		}
+		go func() {
+			_, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Concurrent", Date: date})
+			created <- err
+		}()
+		time.Sleep(10 * time.Millisecond)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn; got %v", err)
+	}
+	if err := <-created; err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	page, err := repos.TodoItem.GetAll(ctx, 1, todoListSber.TodoItemQuery{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Title != "Concurrent" {
+		t.Errorf("expected only the concurrent item; got %+v", page.Items)
+	}
+}
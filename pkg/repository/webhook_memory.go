@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+type memoryWebhook struct {
+	todoListSber.Webhook
+	userId int
+}
+
+type memoryDelivery struct {
+	todoListSber.WebhookDelivery
+	claimedUntil time.Time
+}
+
+// WebhookMemory keeps webhooks and their delivery log in process memory.
+type WebhookMemory struct {
+	mu             sync.RWMutex
+	webhooks       map[int]memoryWebhook
+	deliveries     map[int]memoryDelivery
+	nextId         int
+	nextDeliveryId int
+}
+
+func NewWebhookMemory() *WebhookMemory {
+	return &WebhookMemory{
+		webhooks:       make(map[int]memoryWebhook),
+		deliveries:     make(map[int]memoryDelivery),
+		nextId:         1,
+		nextDeliveryId: 1,
+	}
+}
+func (r *WebhookMemory) Create(ctx context.Context, userId int, webhook todoListSber.Webhook) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhook.Id = r.nextId
+	webhook.Events = slices.Clone(webhook.Events)
+	r.webhooks[webhook.Id] = memoryWebhook{Webhook: webhook, userId: userId}
+	r.nextId++
+	return webhook.Id, nil
+}
+func (r *WebhookMemory) GetAll(ctx context.Context, userId int) ([]todoListSber.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.userWebhooks(userId), nil
+}
+func (r *WebhookMemory) GetById(ctx context.Context, userId, id int) (todoListSber.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok || webhook.userId != userId {
+		return todoListSber.Webhook{}, todoListSber.ErrWebhookNotFound(id)
+	}
+	return webhook.Webhook, nil
+}
+func (r *WebhookMemory) Delete(ctx context.Context, userId, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if webhook, ok := r.webhooks[id]; !ok || webhook.userId != userId {
+		return todoListSber.ErrWebhookNotFound(id)
+	}
+	delete(r.webhooks, id)
+	for deliveryId, delivery := range r.deliveries {
+		if delivery.WebhookId == id {
+			delete(r.deliveries, deliveryId)
+		}
+	}
+	return nil
+}
+func (r *WebhookMemory) Enqueue(ctx context.Context, userId int, event string, payload []byte, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, webhook := range r.userWebhooks(userId) {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		next := now
+		r.deliveries[r.nextDeliveryId] = memoryDelivery{WebhookDelivery: todoListSber.WebhookDelivery{
+			Id:            r.nextDeliveryId,
+			WebhookId:     webhook.Id,
+			Event:         event,
+			Payload:       json.RawMessage(slices.Clone(payload)),
+			Status:        todoListSber.DeliveryPending,
+			NextAttemptAt: &next,
+			CreatedAt:     now,
+		}}
+		r.nextDeliveryId++
+	}
+	return nil
+}
+func (r *WebhookMemory) GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todoListSber.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if webhook, ok := r.webhooks[webhookId]; !ok || webhook.userId != userId {
+		return nil, todoListSber.ErrWebhookNotFound(webhookId)
+	}
+	var deliveries []todoListSber.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookId == webhookId {
+			deliveries = append(deliveries, delivery.WebhookDelivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Id > deliveries[j].Id
+	})
+	if offset >= len(deliveries) {
+		return nil, nil
+	}
+	deliveries = deliveries[offset:]
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
+func (r *WebhookMemory) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.PendingDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var candidates []todoListSber.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status != todoListSber.DeliveryPending || delivery.NextAttemptAt.After(now) || delivery.claimedUntil.After(now) {
+			continue
+		}
+		candidates = append(candidates, delivery.WebhookDelivery)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if !candidates[i].NextAttemptAt.Equal(*candidates[j].NextAttemptAt) {
+			return candidates[i].NextAttemptAt.Before(*candidates[j].NextAttemptAt)
+		}
+		return candidates[i].Id < candidates[j].Id
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	pending := make([]todoListSber.PendingDelivery, len(candidates))
+	for i, candidate := range candidates {
+		delivery := r.deliveries[candidate.Id]
+		delivery.claimedUntil = now.Add(lease)
+		r.deliveries[candidate.Id] = delivery
+		webhook := r.webhooks[candidate.WebhookId]
+		pending[i] = todoListSber.PendingDelivery{WebhookDelivery: candidate, URL: webhook.URL, Secret: webhook.Secret}
+	}
+	return pending, nil
+}
+func (r *WebhookMemory) UpdateDelivery(ctx context.Context, delivery todoListSber.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.deliveries[delivery.Id]
+	if !ok {
+		return todoListSber.ErrWebhookDeliveryNotFound(delivery.Id)
+	}
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.LastAttemptAt = delivery.LastAttemptAt
+	stored.ResponseStatus = delivery.ResponseStatus
+	stored.Error = delivery.Error
+	stored.claimedUntil = time.Time{}
+	r.deliveries[delivery.Id] = stored
+	return nil
+}
+
+// userWebhooks returns the webhooks of userId ordered by id; the caller holds the lock.
+func (r *WebhookMemory) userWebhooks(userId int) []todoListSber.Webhook {
+	var webhooks []todoListSber.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.userId == userId {
+			webhooks = append(webhooks, webhook.Webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].Id < webhooks[j].Id
+	})
+	return webhooks
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+const webhookDeliveryColumns = "id, webhook_id, event, payload, status, attempts, next_attempt_at, last_attempt_at, response_status, error, created_at"
+
+// webhookRow stores the subscribed events as a comma-separated list.
+type webhookRow struct {
+	todoListSber.Webhook
+	Events string `db:"events"`
+}
+
+func (r webhookRow) webhook() todoListSber.Webhook {
+	webhook := r.Webhook
+	if r.Events != "" {
+		webhook.Events = strings.Split(r.Events, ",")
+	}
+	return webhook
+}
+
+type webhookDeliveryRow struct {
+	todoListSber.WebhookDelivery
+	Payload string `db:"payload"`
+}
+
+func (r webhookDeliveryRow) delivery() todoListSber.WebhookDelivery {
+	delivery := r.WebhookDelivery
+	delivery.Payload = json.RawMessage(r.Payload)
+	return delivery
+}
+
+// WebhookSQL implements Webhook for both Postgres and SQLite. Times are written in UTC, as in ReminderSQL.
+type WebhookSQL struct {
+	db dbConn
+}
+
+func NewWebhookSQL(db *sqlx.DB) *WebhookSQL {
+	return &WebhookSQL{db: sqlDB{db}}
+}
+func (r *WebhookSQL) Create(ctx context.Context, userId int, webhook todoListSber.Webhook) (int, error) {
+	var id int
+	query := r.db.Rebind("INSERT INTO webhooks (user_id, url, events, secret, created_at) VALUES (?, ?, ?, ?, ?) RETURNING id")
+	err := r.db.QueryRowxContext(ctx, query, userId, webhook.URL, strings.Join(webhook.Events, ","), webhook.Secret, webhook.CreatedAt.UTC()).Scan(&id)
+	return id, err
+}
+func (r *WebhookSQL) GetAll(ctx context.Context, userId int) ([]todoListSber.Webhook, error) {
+	var rows []webhookRow
+	query := r.db.Rebind("SELECT id, url, events, secret, created_at FROM webhooks WHERE user_id = ? ORDER BY id")
+	if err := r.db.SelectContext(ctx, &rows, query, userId); err != nil {
+		return nil, err
+	}
+	var webhooks []todoListSber.Webhook
+	for _, row := range rows {
+		webhooks = append(webhooks, row.webhook())
+	}
+	return webhooks, nil
+}
+func (r *WebhookSQL) GetById(ctx context.Context, userId, id int) (todoListSber.Webhook, error) {
+	var row webhookRow
+	query := r.db.Rebind("SELECT id, url, events, secret, created_at FROM webhooks WHERE id = ? AND user_id = ?")
+	err := r.db.GetContext(ctx, &row, query, id, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todoListSber.Webhook{}, todoListSber.ErrWebhookNotFound(id)
+	}
+	return row.webhook(), err
+}
+func (r *WebhookSQL) Delete(ctx context.Context, userId, id int) error {
+	res, err := r.db.ExecContext(ctx, r.db.Rebind("DELETE FROM webhooks WHERE id = ? AND user_id = ?"), id, userId)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrWebhookNotFound(id))
+}
+func (r *WebhookSQL) Enqueue(ctx context.Context, userId int, event string, payload []byte, now time.Time) error {
+	webhooks, err := r.GetAll(ctx, userId)
+	if err != nil {
+		return err
+	}
+	now = now.UTC()
+	query := r.db.Rebind("INSERT INTO webhook_deliveries (webhook_id, event, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?, ?)")
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event) {
+			continue
+		}
+		if _, err := r.db.ExecContext(ctx, query, webhook.Id, event, string(payload), now, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (r *WebhookSQL) GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todoListSber.WebhookDelivery, error) {
+	if _, err := r.GetById(ctx, userId, webhookId); err != nil {
+		return nil, err
+	}
+	var rows []webhookDeliveryRow
+	query := r.db.Rebind("SELECT " + webhookDeliveryColumns + " FROM webhook_deliveries WHERE webhook_id = ? ORDER BY id DESC LIMIT ? OFFSET ?")
+	if err := r.db.SelectContext(ctx, &rows, query, webhookId, limit, offset); err != nil {
+		return nil, err
+	}
+	var deliveries []todoListSber.WebhookDelivery
+	for _, row := range rows {
+		deliveries = append(deliveries, row.delivery())
+	}
+	return deliveries, nil
+}
+func (r *WebhookSQL) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]todoListSber.PendingDelivery, error) {
+	now = now.UTC()
+	tx, err := r.db.beginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var candidates []struct {
+		webhookDeliveryRow
+		URL    string `db:"url"`
+		Secret string `db:"secret"`
+	}
+	selectQuery := tx.Rebind(`SELECT d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+			d.last_attempt_at, d.response_status, d.error, d.created_at, w.url, w.secret
+		FROM webhook_deliveries d JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = 'pending' AND d.next_attempt_at <= ? AND (d.claimed_until IS NULL OR d.claimed_until <= ?)
+		ORDER BY d.next_attempt_at, d.id LIMIT ?`)
+	if err := tx.SelectContext(ctx, &candidates, selectQuery, now, now, limit); err != nil {
+		return nil, err
+	}
+
+	// As in ReminderSQL.Claim, the conditional update keeps concurrent dispatchers apart.
+	claimQuery := tx.Rebind("UPDATE webhook_deliveries SET claimed_until = ? WHERE id = ? AND status = 'pending' AND (claimed_until IS NULL OR claimed_until <= ?)")
+	var pending []todoListSber.PendingDelivery
+	for _, candidate := range candidates {
+		res, err := tx.ExecContext(ctx, claimQuery, now.Add(lease), candidate.Id, now)
+		if err != nil {
+			return nil, err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		pending = append(pending, todoListSber.PendingDelivery{
+			WebhookDelivery: candidate.delivery(),
+			URL:             candidate.URL,
+			Secret:          candidate.Secret,
+		})
+	}
+	return pending, tx.Commit()
+}
+func (r *WebhookSQL) UpdateDelivery(ctx context.Context, delivery todoListSber.WebhookDelivery) error {
+	query := r.db.Rebind(`UPDATE webhook_deliveries SET status = ?, attempts = ?, next_attempt_at = ?, last_attempt_at = ?,
+		response_status = ?, error = ?, claimed_until = NULL WHERE id = ?`)
+	res, err := r.db.ExecContext(ctx, query, delivery.Status, delivery.Attempts, utcPtr(delivery.NextAttemptAt),
+		utcPtr(delivery.LastAttemptAt), delivery.ResponseStatus, delivery.Error, delivery.Id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(res, todoListSber.ErrWebhookDeliveryNotFound(delivery.Id))
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	for name, repos := range newTestRepositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			aliceId := createTestUser(t, repos, "alice")
+			bobId := createTestUser(t, repos, "bob")
+			now := time.Now().UTC().Truncate(time.Second)
+
+			allId, err := repos.Webhook.Create(ctx, aliceId, todoListSber.Webhook{URL: "http://example.com/all", Secret: "s1", CreatedAt: now})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			doneId, err := repos.Webhook.Create(ctx, aliceId, todoListSber.Webhook{
+				URL: "http://example.com/done", Events: []string{todoListSber.EventItemCompleted}, Secret: "s2", CreatedAt: now,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			webhook, err := repos.Webhook.GetById(ctx, aliceId, doneId)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(webhook.Events) != 1 || webhook.Events[0] != todoListSber.EventItemCompleted || webhook.Secret != "s2" {
+				t.Errorf("unexpected webhook %+v", webhook)
+			}
+
+			for _, event := range []string{todoListSber.EventItemCreated, todoListSber.EventItemCompleted} {
+				if err := repos.Webhook.Enqueue(ctx, aliceId, event, []byte(`{"event":"`+event+`"}`), now); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			if err := repos.Webhook.Enqueue(ctx, bobId, todoListSber.EventItemCreated, []byte(`{}`), now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			deliveries, err := repos.Webhook.GetDeliveries(ctx, aliceId, allId, 10, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(deliveries) != 2 || deliveries[0].Event != todoListSber.EventItemCompleted || string(deliveries[0].Payload) != `{"event":"item.completed"}` {
+				t.Fatalf("expected both events newest first; got %+v", deliveries)
+			}
+			if deliveries[0].Status != todoListSber.DeliveryPending || deliveries[0].Attempts != 0 {
+				t.Errorf("expected a pending delivery; got %+v", deliveries[0])
+			}
+
+			pending, err := repos.Webhook.ClaimDeliveries(ctx, now, time.Minute, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(pending) != 3 {
+				t.Fatalf("expected the subscribed deliveries of alice; got %+v", pending)
+			}
+			if pending[0].URL != "http://example.com/all" || pending[0].Secret != "s1" {
+				t.Errorf("expected the delivery to carry its webhook; got %+v", pending[0])
+			}
+			if again, err := repos.Webhook.ClaimDeliveries(ctx, now, time.Minute, 10); err != nil || len(again) != 0 {
+				t.Fatalf("expected claimed deliveries to be skipped; got %+v, %v", again, err)
+			}
+
+			// A retry is claimed again at its next attempt; a finished delivery never is.
+			retry := pending[0].WebhookDelivery
+			status, next := 503, now.Add(time.Hour)
+			retry.Attempts, retry.LastAttemptAt, retry.ResponseStatus, retry.NextAttemptAt, retry.Error = 1, &now, &status, &next, "status 503"
+			if err := repos.Webhook.UpdateDelivery(ctx, retry); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, done := range pending[1:] {
+				done.Status, done.Attempts, done.NextAttemptAt = todoListSber.DeliverySucceeded, 1, nil
+				if err := repos.Webhook.UpdateDelivery(ctx, done.WebhookDelivery); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+			pending, err = repos.Webhook.ClaimDeliveries(ctx, now.Add(2*time.Hour), time.Minute, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(pending) != 1 || pending[0].Id != retry.Id || pending[0].Attempts != 1 || *pending[0].ResponseStatus != 503 {
+				t.Fatalf("expected only the retried delivery; got %+v", pending)
+			}
+
+			var notFound *todoListSber.NotFoundError
+			if _, err := repos.Webhook.GetDeliveries(ctx, bobId, allId, 10, 0); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for the webhook of another user; got %v", err)
+			}
+			if err := repos.Webhook.Delete(ctx, bobId, allId); !errors.As(err, &notFound) {
+				t.Errorf("expected NotFoundError for the webhook of another user; got %v", err)
+			}
+			if err := repos.Webhook.Delete(ctx, aliceId, allId); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if pending, err := repos.Webhook.ClaimDeliveries(ctx, now.Add(3*time.Hour), time.Minute, 10); err != nil || len(pending) != 0 {
+				t.Errorf("expected deliveries to be deleted with their webhook; got %+v, %v", pending, err)
+			}
+		})
+	}
+}
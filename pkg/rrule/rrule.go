@@ -0,0 +1,451 @@
+// Package rrule parses and expands the recurrence rules of RFC 5545.
+//
+// The supported subset covers FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY (with ordinals such as 1MO or -1FR in monthly and yearly rules), BYMONTHDAY and BYMONTH.
+// Weeks start on Monday. Other rule parts are rejected by Parse.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxPeriods bounds the expansion of rules that match rarely or never, such as BYMONTHDAY=30;BYMONTH=2.
+const maxPeriods = 100000
+
+// WeekdayNum is a BYDAY entry. A zero N matches every such weekday of the period, a positive N the
+// Nth one from its start and a negative N the Nth one from its end.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+
+	// untilFloating marks an UNTIL without the Z suffix, which is a wall clock time in the
+	// timezone of the start of the series.
+	untilFloating bool
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse parses a rule such as "FREQ=WEEKLY;BYDAY=MO,WE". An "RRULE:" prefix is allowed.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return nil, errors.New("empty recurrence rule")
+	}
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate recurrence rule part %s", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Freq = Frequency(strings.ToUpper(value))
+			switch rule.Freq {
+			case Daily, Weekly, Monthly, Yearly:
+			default:
+				err = fmt.Errorf("unsupported frequency %s", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(name, value)
+		case "COUNT":
+			rule.Count, err = parsePositive(name, value)
+		case "UNTIL":
+			rule.Until, rule.untilFloating, err = parseUntil(value)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(name, value, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseInts(name, value, 1, 12)
+			for _, month := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			if strings.ToUpper(value) != "MO" {
+				err = errors.New("only WKST=MO is supported")
+			}
+		default:
+			err = fmt.Errorf("unsupported recurrence rule part %s", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("recurrence rule must have a FREQ")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return nil, errors.New("COUNT and UNTIL must not be used together")
+	}
+	if rule.Freq == Weekly && len(rule.ByMonthDay) > 0 {
+		return nil, errors.New("BYMONTHDAY is not allowed in a weekly rule")
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly && rule.Freq != Yearly {
+			return nil, errors.New("BYDAY ordinals are only allowed in monthly and yearly rules")
+		}
+	}
+	return rule, nil
+}
+
+func parsePositive(name, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number", name)
+	}
+	return n, nil
+}
+
+func parseInts(name, value string, min, max int) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("invalid %s value %q", name, item)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return until, !strings.HasSuffix(layout, "Z"), nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid UNTIL value %q", value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var result []WeekdayNum
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		weekday, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY value %q", item)
+		}
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", item)
+			}
+			day.N = n
+		}
+		result = append(result, day)
+	}
+	return result, nil
+}
+
+// String formats the rule in the canonical order of its parts.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if !r.Until.IsZero() {
+		switch {
+		case !r.untilFloating:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405Z"))
+		case r.Until.Hour() == 0 && r.Until.Minute() == 0 && r.Until.Second() == 0:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		default:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = int(month)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(values []int) string {
+	items := make([]string, len(values))
+	for i, value := range values {
+		items[i] = strconv.Itoa(value)
+	}
+	return strings.Join(items, ",")
+}
+
+// Between returns at most limit occurrences of the series starting at dtstart that fall within
+// [from, to]. Occurrences keep the wall clock time of dtstart in its location. A zero limit
+// returns every occurrence in the range.
+func (r *Rule) Between(dtstart, from, to time.Time, limit int) []time.Time {
+	var result []time.Time
+	r.iterate(dtstart, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			result = append(result, t)
+		}
+		return limit == 0 || len(result) < limit
+	})
+	return result
+}
+
+// After returns the first occurrence of the series starting at dtstart that is later than t.
+func (r *Rule) After(dtstart, t time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.iterate(dtstart, func(occurrence time.Time) bool {
+		if occurrence.After(t) {
+			next, found = occurrence, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// iterate calls yield with the occurrences in order until it returns false or the series ends.
+// As RFC 5545 requires, dtstart is always the first occurrence.
+func (r *Rule) iterate(dtstart time.Time, yield func(time.Time) bool) {
+	until := r.Until
+	if r.untilFloating && !until.IsZero() {
+		until = time.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), 0, dtstart.Location())
+	}
+	emit := func(t time.Time) bool {
+		if !until.IsZero() && t.After(until) {
+			return false
+		}
+		return yield(t)
+	}
+
+	if !emit(dtstart) {
+		return
+	}
+	count := 1
+	for period := 0; period < maxPeriods; period++ {
+		for _, day := range r.candidates(dtstart, period*r.Interval) {
+			t := time.Date(day.Year(), day.Month(), day.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+			if !t.After(dtstart) {
+				continue
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+			if !emit(t) {
+				return
+			}
+			count++
+		}
+	}
+}
+
+// candidates returns the sorted days of the period that lies offset periods after the one of dtstart.
+// Days are midnight UTC dates; the caller applies the time of day and location of dtstart.
+func (r *Rule) candidates(dtstart time.Time, offset int) []time.Time {
+	start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		day := start.AddDate(0, 0, offset)
+		if r.matchesMonth(day) && r.matchesMonthDay(day) && r.matchesWeekday(day) {
+			days = append(days, day)
+		}
+	case Weekly:
+		monday := start.AddDate(0, 0, -(int(start.Weekday())+6)%7+7*offset)
+		for i := 0; i < 7; i++ {
+			day := monday.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+				continue
+			}
+			if r.matchesMonth(day) && r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		if r.matchesMonth(first) {
+			days = r.daysInRange(first, first.AddDate(0, 1, 0), dtstart)
+		}
+	case Yearly:
+		first := time.Date(start.Year()+offset, time.January, 1, 0, 0, 0, 0, time.UTC)
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, month := range r.ByMonth {
+				monthStart := time.Date(first.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+				days = append(days, r.daysInRange(monthStart, monthStart.AddDate(0, 1, 0), dtstart)...)
+			}
+		case len(r.ByDay) > 0 || len(r.ByMonthDay) > 0:
+			// Without BYMONTH, BYDAY ordinals count within the year and BYMONTHDAY applies to every month.
+			if len(r.ByMonthDay) > 0 {
+				for month := time.January; month <= time.December; month++ {
+					monthStart := time.Date(first.Year(), month, 1, 0, 0, 0, 0, time.UTC)
+					days = append(days, r.daysInRange(monthStart, monthStart.AddDate(0, 1, 0), dtstart)...)
+				}
+			} else {
+				days = r.daysInRange(first, first.AddDate(1, 0, 0), dtstart)
+			}
+		default:
+			day := time.Date(first.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+			if day.Month() == dtstart.Month() {
+				days = append(days, day)
+			}
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return dedupDays(days)
+}
+
+// daysInRange expands BYMONTHDAY and BYDAY within [start, end). When both are given a day has to
+// match both; when neither is, the day of month of dtstart is used and months without it are skipped.
+func (r *Rule) daysInRange(start, end time.Time, dtstart time.Time) []time.Time {
+	var days []time.Time
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for _, monthDay := range r.ByMonthDay {
+			day := start.AddDate(0, 0, monthDay-1)
+			if monthDay < 0 {
+				day = end.AddDate(0, 0, monthDay)
+			}
+			if !day.Before(start) && day.Before(end) && r.matchesWeekdayIn(day, start, end) {
+				days = append(days, day)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			if r.matchesWeekdayIn(day, start, end) {
+				days = append(days, day)
+			}
+		}
+	default:
+		day := start.AddDate(0, 0, dtstart.Day()-1)
+		if day.Before(end) && day.Day() == dtstart.Day() {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func (r *Rule) matchesMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, month := range r.ByMonth {
+		if day.Month() == month {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == day.Day() || monthDay < 0 && daysInMonth+monthDay+1 == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday checks BYDAY without ordinals, as used by daily and weekly rules.
+func (r *Rule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekdayIn checks BYDAY with ordinals counted within [start, end).
+func (r *Rule) matchesWeekdayIn(day, start, end time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, weekday := range r.ByDay {
+		if weekday.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.N == 0:
+			return true
+		case weekday.N > 0 && int(day.Sub(start).Hours()/24)/7+1 == weekday.N:
+			return true
+		case weekday.N < 0 && int(end.Sub(day).Hours()/24-1)/7+1 == -weekday.N:
+			return true
+		}
+	}
+	return false
+}
+
+func dedupDays(days []time.Time) []time.Time {
+	result := days[:0]
+	for i, day := range days {
+		if i == 0 || !day.Equal(days[i-1]) {
+			result = append(result, day)
+		}
+	}
+	return result
+}
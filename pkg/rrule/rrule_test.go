@@ -0,0 +1,142 @@
+package rrule
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBetween(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("no tzdata: %s", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no tzdata: %s", err)
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "Daily",
+			rule:     "FREQ=DAILY;INTERVAL=2",
+			dtstart:  time.Date(2024, time.June, 1, 9, 30, 0, 0, moscow),
+			limit:    3,
+			expected: []string{"2024-06-01 09:30 MSK", "2024-06-03 09:30 MSK", "2024-06-05 09:30 MSK"},
+		},
+		{
+			name:     "Weekly On Weekdays",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			dtstart:  time.Date(2024, time.June, 5, 10, 0, 0, 0, moscow),
+			limit:    4,
+			expected: []string{"2024-06-05 10:00 MSK", "2024-06-07 10:00 MSK", "2024-06-10 10:00 MSK", "2024-06-12 10:00 MSK"},
+		},
+		{
+			name:     "Biweekly",
+			rule:     "FREQ=WEEKLY;INTERVAL=2",
+			dtstart:  time.Date(2024, time.June, 4, 10, 0, 0, 0, moscow),
+			limit:    3,
+			expected: []string{"2024-06-04 10:00 MSK", "2024-06-18 10:00 MSK", "2024-07-02 10:00 MSK"},
+		},
+		{
+			name:     "Monthly On Day Skips Short Months",
+			rule:     "FREQ=MONTHLY",
+			dtstart:  time.Date(2024, time.January, 31, 8, 0, 0, 0, moscow),
+			limit:    3,
+			expected: []string{"2024-01-31 08:00 MSK", "2024-03-31 08:00 MSK", "2024-05-31 08:00 MSK"},
+		},
+		{
+			name:     "Monthly Last Day",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart:  time.Date(2024, time.January, 31, 8, 0, 0, 0, moscow),
+			limit:    3,
+			expected: []string{"2024-01-31 08:00 MSK", "2024-02-29 08:00 MSK", "2024-03-31 08:00 MSK"},
+		},
+		{
+			name:     "Monthly Nth Weekday",
+			rule:     "FREQ=MONTHLY;BYDAY=2TU,-1FR;COUNT=4",
+			dtstart:  time.Date(2024, time.June, 11, 12, 0, 0, 0, moscow),
+			expected: []string{"2024-06-11 12:00 MSK", "2024-06-28 12:00 MSK", "2024-07-09 12:00 MSK", "2024-07-26 12:00 MSK"},
+		},
+		{
+			name:     "Yearly",
+			rule:     "FREQ=YEARLY;BYMONTH=3;BYDAY=1SU;UNTIL=20260101T000000Z",
+			dtstart:  time.Date(2024, time.March, 3, 12, 0, 0, 0, moscow),
+			expected: []string{"2024-03-03 12:00 MSK", "2025-03-02 12:00 MSK"},
+		},
+		{
+			name:     "Keeps Wall Clock Across DST",
+			rule:     "FREQ=WEEKLY",
+			dtstart:  time.Date(2024, time.March, 24, 9, 0, 0, 0, berlin),
+			limit:    2,
+			expected: []string{"2024-03-24 09:00 CET", "2024-03-31 09:00 CEST"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := Parse(test.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			occurrences := rule.Between(test.dtstart, test.dtstart, test.dtstart.AddDate(5, 0, 0), test.limit)
+			var got []string
+			for _, occurrence := range occurrences {
+				got = append(got, occurrence.Format("2006-01-02 15:04 MST"))
+			}
+			if strings.Join(got, ", ") != strings.Join(test.expected, ", ") {
+				t.Errorf("expected %v; got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	rule, err := Parse("RRULE:FREQ=DAILY;COUNT=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dtstart := time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.After(dtstart, dtstart)
+	if !ok || !next.Equal(dtstart.AddDate(0, 0, 1)) {
+		t.Errorf("expected the second occurrence; got %s, %v", next, ok)
+	}
+	if _, ok := rule.After(dtstart, next); ok {
+		t.Errorf("expected the series to end after COUNT occurrences")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	rules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+	}
+	for _, rule := range rules {
+		if _, err := Parse(rule); err == nil {
+			t.Errorf("expected error for %q", rule)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	rule, err := Parse("bymonthday=1,-1;freq=monthly;count=3;interval=2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "FREQ=MONTHLY;INTERVAL=2;COUNT=3;BYMONTHDAY=1,-1"; rule.String() != expected {
+		t.Errorf("expected %q; got %q", expected, rule.String())
+	}
+}
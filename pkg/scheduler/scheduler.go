@@ -0,0 +1,68 @@
+// Package scheduler fires due reminders in the background of the server process.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"todo-list-sber/pkg/notify"
+	"todo-list-sber/pkg/repository"
+)
+
+type Config struct {
+	// PollInterval is how often due reminders are looked for.
+	PollInterval time.Duration
+	// Lease is how long a claimed reminder is reserved for one delivery attempt. A reminder whose
+	// delivery failed, or whose process died while delivering it, is retried once the lease expires.
+	Lease     time.Duration
+	BatchSize int
+}
+
+// Scheduler delivers every reminder once: a reminder is claimed in the database before delivery
+// and marked sent after it, so neither a restart nor a second instance fires it again. Reminders
+// that fell due while the server was down are delivered on start.
+type Scheduler struct {
+	repo     repository.Reminder
+	notifier notify.Notifier
+	cfg      Config
+	now      func() time.Time
+}
+
+func New(repo repository.Reminder, notifier notify.Notifier, cfg Config) *Scheduler {
+	return &Scheduler{repo: repo, notifier: notifier, cfg: cfg, now: time.Now}
+}
+
+// Run fires due reminders every PollInterval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for s.Tick(ctx) == s.cfg.BatchSize && ctx.Err() == nil {
+			// A full batch suggests a backlog, e.g. after downtime; keep going without waiting.
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick claims one batch of due reminders and delivers them. It returns the number claimed.
+func (s *Scheduler) Tick(ctx context.Context) int {
+	due, err := s.repo.Claim(ctx, s.now(), s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		slog.Error("error claiming reminders", "error", err)
+		return 0
+	}
+	for _, reminder := range due {
+		if err := s.notifier.Notify(ctx, reminder); err != nil {
+			slog.Warn("error delivering reminder, retrying after the lease", "reminder_id", reminder.Id, "error", err)
+			continue
+		}
+		if err := s.repo.MarkSent(ctx, reminder.Id, s.now()); err != nil {
+			slog.Error("error marking reminder sent", "reminder_id", reminder.Id, "error", err)
+		}
+	}
+	return len(due)
+}
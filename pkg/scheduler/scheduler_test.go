@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+type fakeNotifier struct {
+	sent []int
+	err  error
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, reminder todoListSber.DueReminder) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, reminder.Id)
+	return nil
+}
+
+func TestSchedulerTick(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	now := time.Now().UTC()
+
+	itemId, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	at := now.Add(-time.Minute)
+	id, err := repos.Reminder.Create(ctx, 1, itemId, todoListSber.Reminder{At: &at, RemindAt: at})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	notifier := &fakeNotifier{err: errors.New("unavailable")}
+	s := New(repos.Reminder, notifier, Config{PollInterval: time.Second, Lease: time.Minute, BatchSize: 10})
+	s.now = func() time.Time { return now }
+	if n := s.Tick(ctx); n != 1 || len(notifier.sent) != 0 {
+		t.Fatalf("expected a failed delivery; claimed %d, sent %v", n, notifier.sent)
+	}
+
+	// The failed reminder is retried once its lease has expired, and only then.
+	notifier.err = nil
+	if n := s.Tick(ctx); n != 0 {
+		t.Fatalf("expected the leased reminder to be skipped; claimed %d", n)
+	}
+	s.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if s.Tick(ctx); len(notifier.sent) != 1 || notifier.sent[0] != id {
+		t.Fatalf("expected the reminder to be delivered; sent %v", notifier.sent)
+	}
+
+	s.now = func() time.Time { return now.Add(time.Hour) }
+	if s.Tick(ctx); len(notifier.sent) != 1 {
+		t.Errorf("expected the reminder to be delivered once; sent %v", notifier.sent)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	UserId int `json:"user_id"`
+}
+
+type AuthService struct {
+	repo       repository.Authorization
+	signingKey []byte
+	tokenTTL   time.Duration
+}
+
+func NewAuthService(repo repository.Authorization, signingKey []byte, tokenTTL time.Duration) *AuthService {
+	return &AuthService{repo: repo, signingKey: signingKey, tokenTTL: tokenTTL}
+}
+func (s *AuthService) CreateUser(ctx context.Context, user todoListSber.User) (int, error) {
+	if err := user.Validate(); err != nil {
+		return 0, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+	user.PasswordHash = string(hash)
+	return s.repo.CreateUser(ctx, user)
+}
+func (s *AuthService) GenerateToken(ctx context.Context, username, password string) (string, error) {
+	user, err := s.repo.GetUser(ctx, username)
+	var notFound *todoListSber.NotFoundError
+	if errors.As(err, &notFound) {
+		return "", todoListSber.ErrInvalidCredentials
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return "", todoListSber.ErrInvalidCredentials
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenTTL)),
+		},
+		UserId: user.Id,
+	})
+	return token.SignedString(s.signingKey)
+}
+func (s *AuthService) ParseToken(accessToken string) (int, error) {
+	token, err := jwt.ParseWithClaims(accessToken, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return s.signingKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return 0, &todoListSber.UnauthorizedError{Message: fmt.Sprintf("invalid access token: %s", err)}
+	}
+	claims, ok := token.Claims.(*tokenClaims)
+	if !ok || claims.UserId <= 0 {
+		return 0, &todoListSber.UnauthorizedError{Message: "invalid access token claims"}
+	}
+	return claims.UserId, nil
+}
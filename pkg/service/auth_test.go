@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"strings"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+var testSigningKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestAuthServiceTokens(t *testing.T) {
+	ctx := context.Background()
+	s := NewAuthService(repository.NewAuthMemory(), testSigningKey, time.Hour)
+
+	id, err := s.CreateUser(ctx, todoListSber.User{Username: "alice", Password: "qwerty123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	token, err := s.GenerateToken(ctx, "alice", "qwerty123")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	userId, err := s.ParseToken(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if userId != id {
+		t.Errorf("expected user id %d; got %d", id, userId)
+	}
+
+	var unauthorized *todoListSber.UnauthorizedError
+	if _, err := s.GenerateToken(ctx, "alice", "wrong-password"); !errors.As(err, &unauthorized) {
+		t.Errorf("expected UnauthorizedError for a wrong password; got %v", err)
+	}
+	if _, err := s.GenerateToken(ctx, "bob", "qwerty123"); !errors.As(err, &unauthorized) {
+		t.Errorf("expected UnauthorizedError for an unknown user; got %v", err)
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.CreateUser(ctx, todoListSber.User{Username: "bob", Password: strings.Repeat("x", 73)}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for a password bcrypt cannot hash; got %v", err)
+	}
+}
+
+func TestAuthServiceParseToken(t *testing.T) {
+	s := NewAuthService(repository.NewAuthMemory(), testSigningKey, time.Hour)
+	sign := func(method jwt.SigningMethod, key interface{}, claims *tokenClaims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("error signing token: %s", err)
+		}
+		return token
+	}
+	valid := func() *tokenClaims {
+		return &tokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+			UserId:           1,
+		}
+	}
+	expired := valid()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := valid()
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "Malformed", token: "not-a-token"},
+		{name: "Wrong Key", token: sign(jwt.SigningMethodHS256, []byte("another-key-another-key-another!"), valid())},
+		{name: "Wrong Method", token: sign(jwt.SigningMethodHS512, testSigningKey, valid())},
+		{name: "Expired", token: sign(jwt.SigningMethodHS256, testSigningKey, expired)},
+		{name: "No Expiry", token: sign(jwt.SigningMethodHS256, testSigningKey, noExpiry)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var unauthorized *todoListSber.UnauthorizedError
+			if _, err := s.ParseToken(test.token); !errors.As(err, &unauthorized) {
+				t.Errorf("expected UnauthorizedError; got %v", err)
+			}
+		})
+	}
+}
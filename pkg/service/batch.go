@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// BatchService runs batches of item operations, every batch in a single transaction. The
+// operations are checked and publish their events like the same calls of TodoItemService, but the
+// events are only recorded once the batch has committed.
+type BatchService struct {
+	repos  *repository.Repository
+	events *EventService
+}
+
+func NewBatchService(repos *repository.Repository, events *EventService) *BatchService {
+	return &BatchService{repos: repos, events: events}
+}
+
+// errBatchFailed rolls back the transaction of an atomic batch whose operation failed.
+var errBatchFailed = errors.New("batch operation failed")
+
+// Run runs the operations of batch in order and returns a result for each of them. The first
+// operation to fail in an atomic batch rolls back all the others, which then report
+// ErrBatchRolledBack. In a partial batch a failed operation is rolled back on its own and the rest
+// still run. The error is only set when the batch could not run at all.
+func (s *BatchService) Run(ctx context.Context, userId int, batch todoListSber.Batch) ([]todoListSber.BatchResult, error) {
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+	results := make([]todoListSber.BatchResult, len(batch.Operations))
+	held := s.events.hold()
+	failed := -1
+
+	err := s.repos.Transaction(ctx, func(tx *repository.Repository) error {
+		for i, operation := range batch.Operations {
+			if batch.Atomic() {
+				results[i] = s.run(ctx, tx, held, userId, operation)
+				if results[i].Err != nil {
+					failed = i
+					return errBatchFailed
+				}
+				continue
+			}
+
+			published := len(held.held)
+			err := tx.Transaction(ctx, func(tx *repository.Repository) error {
+				results[i] = s.run(ctx, tx, held, userId, operation)
+				return results[i].Err
+			})
+			if err != nil && results[i].Err == nil {
+				return err
+			}
+			if err != nil {
+				held.held = held.held[:published]
+			}
+		}
+		return nil
+	})
+
+	if failed >= 0 {
+		for i := range results {
+			if i != failed {
+				results[i] = todoListSber.BatchResult{Id: batch.Operations[i].Id, Err: todoListSber.ErrBatchRolledBack(failed)}
+			}
+		}
+		return results, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.events.release(ctx, held)
+	return results, nil
+}
+
+// run runs a single operation on the repositories of the transaction of its batch.
+func (s *BatchService) run(ctx context.Context, repos *repository.Repository, events *EventService, userId int, operation todoListSber.BatchOperation) todoListSber.BatchResult {
+	items := NewTodoItemService(repos, events)
+	result := todoListSber.BatchResult{Id: operation.Id}
+	switch operation.Op {
+	case todoListSber.BatchCreate:
+		id, err := items.Create(ctx, userId, *operation.Item)
+		if err == nil {
+			result.Id = id
+		}
+		result.Err = err
+	case todoListSber.BatchUpdate:
+		patch := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: operation.Patch, Version: operation.Version}
+		result.Err = items.Patch(ctx, userId, operation.Id, patch)
+	case todoListSber.BatchDelete:
+		result.Err = items.Delete(ctx, userId, operation.Id)
+	case todoListSber.BatchComplete:
+		isDone := true
+		input := todoListSber.UpdateItemInput{IsDone: &isDone, Cascade: operation.Cascade, Version: operation.Version}
+		result.Err = items.Update(ctx, userId, operation.Id, input)
+	}
+	return result
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestBatchService(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T) (*repository.Repository, *TodoItemService, *BatchService) {
+		repos := repository.NewMemoryRepository()
+		events := NewEventService(repos.EventLog, repos.Webhook, 100)
+		return repos, NewTodoItemService(repos, events), NewBatchService(repos, events)
+	}
+	countEvents := func(t *testing.T, repos *repository.Repository) int {
+		t.Helper()
+		events, err := repos.EventLog.After(ctx, 1, 0, 100)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return len(events)
+	}
+
+	t.Run("Atomic", func(t *testing.T) {
+		repos, items, s := setup(t)
+		blockerId, err := items.Create(ctx, 1, todoListSber.TodoItem{Title: "Blocker", Date: date})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		blockedId, err := items.Create(ctx, 1, todoListSber.TodoItem{Title: "Blocked", Date: date})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := repos.Dependency.Add(ctx, 1, blockedId, blockerId); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		before := countEvents(t, repos)
+
+		results, err := s.Run(ctx, 1, todoListSber.Batch{Operations: []todoListSber.BatchOperation{
+			{Op: todoListSber.BatchCreate, Item: &todoListSber.TodoItem{Title: "New", Date: date}},
+			{Op: todoListSber.BatchComplete, Id: blockerId},
+			{Op: todoListSber.BatchComplete, Id: blockedId},
+			{Op: todoListSber.BatchUpdate, Id: blockerId, Patch: json.RawMessage(`{"description": "done first"}`)},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for i, result := range results {
+			if result.Err != nil {
+				t.Errorf("unexpected error of operation %d: %s", i, result.Err)
+			}
+		}
+		if results[0].Id == 0 || results[1].Id != blockerId {
+			t.Errorf("unexpected ids %+v", results)
+		}
+		blocked, err := items.GetById(ctx, 1, blockedId)
+		if err != nil || !blocked.IsDone {
+			t.Errorf("expected the item done once its blocker is done in the same batch; got %+v, %v", blocked, err)
+		}
+		// item.created, two of item.updated with item.completed, and item.updated.
+		if published := countEvents(t, repos) - before; published != 6 {
+			t.Errorf("expected 6 events after the commit; got %d", published)
+		}
+	})
+
+	t.Run("Atomic Rollback", func(t *testing.T) {
+		repos, items, s := setup(t)
+		id, err := items.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		before := countEvents(t, repos)
+
+		results, err := s.Run(ctx, 1, todoListSber.Batch{Mode: todoListSber.BatchAtomic, Operations: []todoListSber.BatchOperation{
+			{Op: todoListSber.BatchComplete, Id: id},
+			{Op: todoListSber.BatchCreate, Item: &todoListSber.TodoItem{Title: "New", Date: date}},
+			{Op: todoListSber.BatchDelete, Id: 42},
+			{Op: todoListSber.BatchDelete, Id: id},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var notFound *todoListSber.NotFoundError
+		if !errors.As(results[2].Err, &notFound) {
+			t.Errorf("expected NotFoundError for the failed operation; got %v", results[2].Err)
+		}
+		var rolledBack *todoListSber.FailedDependencyError
+		for _, i := range []int{0, 1, 3} {
+			if !errors.As(results[i].Err, &rolledBack) {
+				t.Errorf("expected FailedDependencyError for operation %d; got %v", i, results[i].Err)
+			}
+		}
+
+		item, err := items.GetById(ctx, 1, id)
+		if err != nil || item.IsDone {
+			t.Errorf("expected the completion rolled back; got %+v, %v", item, err)
+		}
+		page, err := items.GetAll(ctx, 1, todoListSber.TodoItemQuery{})
+		if err != nil || page.Total != 1 {
+			t.Errorf("expected the create rolled back; got %d items, %v", page.Total, err)
+		}
+		if published := countEvents(t, repos) - before; published != 0 {
+			t.Errorf("expected no events of a rolled back batch; got %d", published)
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		repos, items, s := setup(t)
+		id, err := items.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		before := countEvents(t, repos)
+		stale := 5
+
+		results, err := s.Run(ctx, 1, todoListSber.Batch{Mode: todoListSber.BatchPartial, Operations: []todoListSber.BatchOperation{
+			{Op: todoListSber.BatchUpdate, Id: id, Patch: json.RawMessage(`{"title": "Renamed", "tags": ["work"]}`)},
+			{Op: todoListSber.BatchUpdate, Id: id, Patch: json.RawMessage(`{"title": ""}`)},
+			{Op: todoListSber.BatchComplete, Id: id, Version: &stale},
+			{Op: todoListSber.BatchCreate, Item: &todoListSber.TodoItem{Title: "New", Date: date}},
+		}})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var validation *todoListSber.ValidationError
+		var mismatch *todoListSber.PreconditionFailedError
+		if results[0].Err != nil || !errors.As(results[1].Err, &validation) || !errors.As(results[2].Err, &mismatch) || results[3].Err != nil {
+			t.Errorf("unexpected results %+v", results)
+		}
+
+		item, err := items.GetById(ctx, 1, id)
+		if err != nil || item.Title != "Renamed" || len(item.Tags) != 1 || item.IsDone {
+			t.Errorf("expected only the first update applied; got %+v, %v", item, err)
+		}
+		if _, err := items.GetById(ctx, 1, results[3].Id); err != nil {
+			t.Errorf("expected the created item; got %v", err)
+		}
+		if published := countEvents(t, repos) - before; published != 2 {
+			t.Errorf("expected the events of the two successful operations; got %d", published)
+		}
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, _, s := setup(t)
+		batches := map[string]todoListSber.Batch{
+			"Empty":        {},
+			"Unknown Mode": {Mode: "sometimes", Operations: []todoListSber.BatchOperation{{Op: todoListSber.BatchDelete, Id: 1}}},
+			"Unknown Op":   {Operations: []todoListSber.BatchOperation{{Op: "archive", Id: 1}}},
+			"Missing Id":   {Operations: []todoListSber.BatchOperation{{Op: todoListSber.BatchComplete}}},
+			"Missing Item": {Operations: []todoListSber.BatchOperation{{Op: todoListSber.BatchCreate}}},
+			"Too Many":     {Operations: make([]todoListSber.BatchOperation, todoListSber.MaxBatchOperations+1)},
+		}
+		for name, batch := range batches {
+			t.Run(name, func(t *testing.T) {
+				var validation *todoListSber.ValidationError
+				if _, err := s.Run(ctx, 1, batch); !errors.As(err, &validation) {
+					t.Errorf("expected ValidationError; got %v", err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+type DependencyService struct {
+	repo repository.Dependency
+}
+
+func NewDependencyService(repo repository.Dependency) *DependencyService {
+	return &DependencyService{repo: repo}
+}
+func (s *DependencyService) GetBlockers(ctx context.Context, userId, itemId int) ([]todoListSber.TodoItem, error) {
+	return s.repo.GetBlockers(ctx, userId, itemId)
+}
+func (s *DependencyService) Add(ctx context.Context, userId, itemId, blockerId int) error {
+	return s.repo.Add(ctx, userId, itemId, blockerId)
+}
+func (s *DependencyService) Remove(ctx context.Context, userId, itemId, blockerId int) error {
+	return s.repo.Remove(ctx, userId, itemId, blockerId)
+}
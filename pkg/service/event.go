@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// EventService publishes item events. Every event is queued for the webhooks of the owner of the
+// item and appended to the buffer the event stream resumes from; the streams of the owner in this
+// process are woken at once, those of other processes find the event when they poll the buffer.
+type EventService struct {
+	repo        repository.EventLog
+	webhookRepo repository.Webhook
+	bufferSize  int
+
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+
+	// holding makes Publish collect events in held instead of recording them, see hold.
+	holding bool
+	held    []heldEvent
+}
+
+// heldEvent is an event published through an EventService returned by hold.
+type heldEvent struct {
+	userId int
+	event  string
+	item   todoListSber.TodoItem
+}
+
+// NewEventService creates an EventService keeping the newest bufferSize events of every user.
+func NewEventService(repo repository.EventLog, webhookRepo repository.Webhook, bufferSize int) *EventService {
+	return &EventService{
+		repo:        repo,
+		webhookRepo: webhookRepo,
+		bufferSize:  bufferSize,
+		subscribers: make(map[int]map[chan struct{}]struct{}),
+	}
+}
+
+// Publish records event about item of userId. The change has already been made, so failures are
+// logged instead of failing the request, and the event is recorded even when the request has been
+// canceled meanwhile.
+func (s *EventService) Publish(ctx context.Context, userId int, event string, item todoListSber.TodoItem) {
+	if s.holding {
+		s.held = append(s.held, heldEvent{userId: userId, event: event, item: item})
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	now := time.Now().UTC()
+	payload, err := json.Marshal(todoListSber.ItemEvent{Event: event, CreatedAt: now, Item: item})
+	if err != nil {
+		slog.Error("error encoding item event", "event", event, "item_id", item.Id, "error", err)
+		return
+	}
+	if err := s.webhookRepo.Enqueue(ctx, userId, event, payload, now); err != nil {
+		slog.Error("error queueing item event", "event", event, "item_id", item.Id, "error", err)
+	}
+	stored := todoListSber.StoredEvent{Event: event, Payload: payload, CreatedAt: now}
+	if _, err := s.repo.Append(ctx, userId, stored, s.bufferSize); err != nil {
+		slog.Error("error buffering item event", "event", event, "item_id", item.Id, "error", err)
+		return
+	}
+	s.notify(userId)
+}
+
+// Since returns up to limit events of userId following lastId. lost reports that events after
+// lastId have already been trimmed from the buffer, so the client has to reload its state.
+func (s *EventService) Since(ctx context.Context, userId, lastId, limit int) (events []todoListSber.StoredEvent, lost bool, err error) {
+	if limit <= 0 {
+		return nil, false, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	if lastId > 0 {
+		has, err := s.repo.Has(ctx, userId, lastId)
+		if err != nil {
+			return nil, false, err
+		}
+		if !has {
+			latest, err := s.repo.Latest(ctx, userId)
+			if err != nil || latest > lastId {
+				return nil, err == nil, err
+			}
+		}
+	}
+	events, err = s.repo.After(ctx, userId, lastId, limit)
+	return events, false, err
+}
+func (s *EventService) Latest(ctx context.Context, userId int) (int, error) {
+	return s.repo.Latest(ctx, userId)
+}
+
+// Subscribe returns a channel receiving a value whenever an event of userId is published in this
+// process, and a function that ends the subscription. Wake-ups coalesce while the channel is full.
+func (s *EventService) Subscribe(userId int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	if s.subscribers[userId] == nil {
+		s.subscribers[userId] = make(map[chan struct{}]struct{})
+	}
+	s.subscribers[userId][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[userId], ch)
+		if len(s.subscribers[userId]) == 0 {
+			delete(s.subscribers, userId)
+		}
+	}
+}
+
+func (s *EventService) notify(userId int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[userId] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// hold returns an EventService that collects the events published through it, for the services
+// working inside a transaction: its events must only be recorded once it has committed, by release.
+func (s *EventService) hold() *EventService {
+	return &EventService{holding: true}
+}
+
+// release publishes the events collected by held, in the order they were published.
+func (s *EventService) release(ctx context.Context, held *EventService) {
+	for _, e := range held.held {
+		s.Publish(ctx, e.userId, e.event, e.item)
+	}
+}
+
+// publishUpdated publishes item.updated for the items ids of userId as they are now.
+func (s *EventService) publishUpdated(ctx context.Context, itemRepo repository.TodoItem, userId int, ids []int) {
+	ctx = context.WithoutCancel(ctx)
+	for _, id := range ids {
+		item, err := itemRepo.GetById(ctx, userId, id)
+		if err != nil {
+			slog.Error("error loading updated item for its event", "item_id", id, "error", err)
+			continue
+		}
+		s.Publish(ctx, userId, todoListSber.EventItemUpdated, item)
+	}
+}
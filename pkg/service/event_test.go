@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestEventServiceSince(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(repos.EventLog, repos.Webhook, 2)
+	wake, unsubscribe := events.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < 3; i++ {
+		events.Publish(ctx, 1, todoListSber.EventItemCreated, todoListSber.TodoItem{Id: i + 1, Title: "Task"})
+	}
+	select {
+	case <-wake:
+	default:
+		t.Fatalf("expected the subscriber to be woken")
+	}
+
+	got, lost, err := events.Since(ctx, 1, 2, 10)
+	if err != nil || lost || len(got) != 1 || got[0].Id != 3 {
+		t.Errorf("expected the event after 2; got %+v, %v, %v", got, lost, err)
+	}
+	if got, lost, err := events.Since(ctx, 1, 1, 10); err != nil || !lost || len(got) != 0 {
+		t.Errorf("expected event 1 to be reported lost; got %+v, %v, %v", got, lost, err)
+	}
+	if got, lost, err := events.Since(ctx, 1, 3, 10); err != nil || lost || len(got) != 0 {
+		t.Errorf("expected no events after the latest; got %+v, %v, %v", got, lost, err)
+	}
+}
+
+// cancelAwareLog refuses to append events with a canceled context, like the SQL event log does.
+type cancelAwareLog struct {
+	repository.EventLog
+}
+
+func (r cancelAwareLog) Append(ctx context.Context, userId int, event todoListSber.StoredEvent, keep int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.EventLog.Append(ctx, userId, event, keep)
+}
+
+func TestEventServicePublishCanceled(t *testing.T) {
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(cancelAwareLog{repos.EventLog}, repos.Webhook, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The change the event is about has been made, so a request gone meanwhile does not drop it.
+	events.Publish(ctx, 1, todoListSber.EventItemCreated, todoListSber.TodoItem{Id: 1, Title: "Task"})
+	if got, _, err := events.Since(context.Background(), 1, 0, 10); err != nil || len(got) != 1 {
+		t.Errorf("expected the event to be recorded; got %+v, %v", got, err)
+	}
+}
+
+func TestEventServiceWritePaths(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(repos.EventLog, repos.Webhook, 100)
+	items := NewTodoItemService(repos, events)
+	lists := NewTodoListService(repos.TodoList, repos.TodoItem, events)
+	tags := NewTagService(repos.Tag, repos.TodoItem, events)
+
+	listId, err := lists.Create(ctx, 1, todoListSber.TodoList{Title: "Home"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	itemId, err := items.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: time.Now(), ListId: &listId})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tagId, err := tags.Create(ctx, 1, todoListSber.Tag{Name: "home"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tags.Attach(ctx, 1, itemId, tagId); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := tags.Update(ctx, 1, tagId, todoListSber.Tag{Name: "house"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := lists.Delete(ctx, 1, listId, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, _, err := events.Since(ctx, 1, 0, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []string{todoListSber.EventItemCreated, todoListSber.EventItemUpdated, todoListSber.EventItemUpdated, todoListSber.EventItemDeleted}
+	if len(got) != len(expected) {
+		t.Fatalf("expected events %v; got %+v", expected, got)
+	}
+	for i, event := range got {
+		if event.Event != expected[i] {
+			t.Errorf("expected event %d to be %s; got %s", i, expected[i], event.Event)
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package mock_service
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 	todo_list_sber "todo-list-sber"
@@ -12,6 +13,74 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
+// MockAuthorization is a mock of Authorization interface.
+type MockAuthorization struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorizationMockRecorder
+}
+
+// MockAuthorizationMockRecorder is the mock recorder for MockAuthorization.
+type MockAuthorizationMockRecorder struct {
+	mock *MockAuthorization
+}
+
+// NewMockAuthorization creates a new mock instance.
+func NewMockAuthorization(ctrl *gomock.Controller) *MockAuthorization {
+	mock := &MockAuthorization{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockAuthorization) CreateUser(ctx context.Context, user todo_list_sber.User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockAuthorizationMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), ctx, user)
+}
+
+// GenerateToken mocks base method.
+func (m *MockAuthorization) GenerateToken(ctx context.Context, username, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", ctx, username, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockAuthorizationMockRecorder) GenerateToken(ctx, username, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), ctx, username, password)
+}
+
+// ParseToken mocks base method.
+func (m *MockAuthorization) ParseToken(accessToken string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", accessToken)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAuthorizationMockRecorder) ParseToken(accessToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), accessToken)
+}
+
 // MockTodoItem is a mock of TodoItem interface.
 type MockTodoItem struct {
 	ctrl     *gomock.Controller
@@ -36,104 +105,851 @@ func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockTodoItem) Create(todoItem todo_list_sber.TodoItem) (int, error) {
+func (m *MockTodoItem) Create(ctx context.Context, userId int, todoItem todo_list_sber.TodoItem) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", todoItem)
+	ret := m.ctrl.Call(m, "Create", ctx, userId, todoItem)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoItemMockRecorder) Create(todoItem interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) Create(ctx, userId, todoItem interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), todoItem)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), ctx, userId, todoItem)
 }
 
 // Delete mocks base method.
-func (m *MockTodoItem) Delete(id int) error {
+func (m *MockTodoItem) Delete(ctx context.Context, userId, id int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoItemMockRecorder) Delete(id interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) Delete(ctx, userId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), ctx, userId, id)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoItem) GetAll() ([]todo_list_sber.TodoItem, error) {
+func (m *MockTodoItem) GetAll(ctx context.Context, userId int, query todo_list_sber.TodoItemQuery) (todo_list_sber.TodoItemPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
-	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId, query)
+	ret0, _ := ret[0].(todo_list_sber.TodoItemPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoItemMockRecorder) GetAll() *gomock.Call {
+func (mr *MockTodoItemMockRecorder) GetAll(ctx, userId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), ctx, userId, query)
+}
+
+// GetByCursor mocks base method.
+func (m *MockTodoItem) GetByCursor(ctx context.Context, userId int, query todo_list_sber.TodoItemQuery, cursor *todo_list_sber.ItemCursor) (todo_list_sber.TodoItemCursorPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCursor", ctx, userId, query, cursor)
+	ret0, _ := ret[0].(todo_list_sber.TodoItemCursorPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCursor indicates an expected call of GetByCursor.
+func (mr *MockTodoItemMockRecorder) GetByCursor(ctx, userId, query, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCursor", reflect.TypeOf((*MockTodoItem)(nil).GetByCursor), ctx, userId, query, cursor)
 }
 
 // GetById mocks base method.
-func (m *MockTodoItem) GetById(id int) (todo_list_sber.TodoItem, error) {
+func (m *MockTodoItem) GetById(ctx context.Context, userId, id int) (todo_list_sber.TodoItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", id)
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
 	ret0, _ := ret[0].(todo_list_sber.TodoItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockTodoItemMockRecorder) GetById(id interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), ctx, userId, id)
+}
+
+// GetByList mocks base method.
+func (m *MockTodoItem) GetByList(ctx context.Context, userId, listId int, filter todo_list_sber.TagFilter, limit, offset int) (todo_list_sber.TodoItemPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByList", ctx, userId, listId, filter, limit, offset)
+	ret0, _ := ret[0].(todo_list_sber.TodoItemPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByList indicates an expected call of GetByList.
+func (mr *MockTodoItemMockRecorder) GetByList(ctx, userId, listId, filter, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByList", reflect.TypeOf((*MockTodoItem)(nil).GetByList), ctx, userId, listId, filter, limit, offset)
+}
+
+// GetChildren mocks base method.
+func (m *MockTodoItem) GetChildren(ctx context.Context, userId, id int) ([]todo_list_sber.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChildren", ctx, userId, id)
+	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChildren indicates an expected call of GetChildren.
+func (mr *MockTodoItemMockRecorder) GetChildren(ctx, userId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChildren", reflect.TypeOf((*MockTodoItem)(nil).GetChildren), ctx, userId, id)
 }
 
 // GetDoneTodoItems mocks base method.
-func (m *MockTodoItem) GetDoneTodoItems(date *time.Time, limit, offset int) ([]todo_list_sber.TodoItem, error) {
+func (m *MockTodoItem) GetDoneTodoItems(ctx context.Context, userId int, date *time.Time, limit, offset int, filter todo_list_sber.TagFilter) ([]todo_list_sber.TodoItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDoneTodoItems", date, limit, offset)
+	ret := m.ctrl.Call(m, "GetDoneTodoItems", ctx, userId, date, limit, offset, filter)
 	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDoneTodoItems indicates an expected call of GetDoneTodoItems.
-func (mr *MockTodoItemMockRecorder) GetDoneTodoItems(date, limit, offset interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) GetDoneTodoItems(ctx, userId, date, limit, offset, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoneTodoItems", reflect.TypeOf((*MockTodoItem)(nil).GetDoneTodoItems), ctx, userId, date, limit, offset, filter)
+}
+
+// GetHistory mocks base method.
+func (m *MockTodoItem) GetHistory(ctx context.Context, userId, id int) ([]todo_list_sber.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, userId, id)
+	ret0, _ := ret[0].([]todo_list_sber.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockTodoItemMockRecorder) GetHistory(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockTodoItem)(nil).GetHistory), ctx, userId, id)
+}
+
+// GetOccurrences mocks base method.
+func (m *MockTodoItem) GetOccurrences(ctx context.Context, userId, id int, from, to time.Time, limit int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrences", ctx, userId, id, from, to, limit)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrences indicates an expected call of GetOccurrences.
+func (mr *MockTodoItemMockRecorder) GetOccurrences(ctx, userId, id, from, to, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrences", reflect.TypeOf((*MockTodoItem)(nil).GetOccurrences), ctx, userId, id, from, to, limit)
+}
+
+// GetTrash mocks base method.
+func (m *MockTodoItem) GetTrash(ctx context.Context, userId, limit, offset int) ([]todo_list_sber.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userId, limit, offset)
+	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTodoItemMockRecorder) GetTrash(ctx, userId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTodoItem)(nil).GetTrash), ctx, userId, limit, offset)
+}
+
+// GetTree mocks base method.
+func (m *MockTodoItem) GetTree(ctx context.Context, userId, id int) (todo_list_sber.TodoItemNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTree", ctx, userId, id)
+	ret0, _ := ret[0].(todo_list_sber.TodoItemNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTree indicates an expected call of GetTree.
+func (mr *MockTodoItemMockRecorder) GetTree(ctx, userId, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoneTodoItems", reflect.TypeOf((*MockTodoItem)(nil).GetDoneTodoItems), date, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockTodoItem)(nil).GetTree), ctx, userId, id)
 }
 
 // GetUndoneTodoItems mocks base method.
-func (m *MockTodoItem) GetUndoneTodoItems(date *time.Time, limit, offset int) ([]todo_list_sber.TodoItem, error) {
+func (m *MockTodoItem) GetUndoneTodoItems(ctx context.Context, userId int, date *time.Time, limit, offset int, filter todo_list_sber.TagFilter) ([]todo_list_sber.TodoItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUndoneTodoItems", date, limit, offset)
+	ret := m.ctrl.Call(m, "GetUndoneTodoItems", ctx, userId, date, limit, offset, filter)
 	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUndoneTodoItems indicates an expected call of GetUndoneTodoItems.
-func (mr *MockTodoItemMockRecorder) GetUndoneTodoItems(date, limit, offset interface{}) *gomock.Call {
+func (mr *MockTodoItemMockRecorder) GetUndoneTodoItems(ctx, userId, date, limit, offset, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndoneTodoItems", reflect.TypeOf((*MockTodoItem)(nil).GetUndoneTodoItems), ctx, userId, date, limit, offset, filter)
+}
+
+// Move mocks base method.
+func (m *MockTodoItem) Move(ctx context.Context, userId, id int, input todo_list_sber.MoveItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", ctx, userId, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(ctx, userId, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), ctx, userId, id, input)
+}
+
+// Patch mocks base method.
+func (m *MockTodoItem) Patch(ctx context.Context, userId, id int, patch todo_list_sber.ItemPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, userId, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockTodoItemMockRecorder) Patch(ctx, userId, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockTodoItem)(nil).Patch), ctx, userId, id, patch)
+}
+
+// Replace mocks base method.
+func (m *MockTodoItem) Replace(ctx context.Context, userId, id int, fields todo_list_sber.ItemFields) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, userId, id, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTodoItemMockRecorder) Replace(ctx, userId, id, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTodoItem)(nil).Replace), ctx, userId, id, fields)
+}
+
+// Restore mocks base method.
+func (m *MockTodoItem) Restore(ctx context.Context, userId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTodoItemMockRecorder) Restore(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTodoItem)(nil).Restore), ctx, userId, id)
+}
+
+// Revert mocks base method.
+func (m *MockTodoItem) Revert(ctx context.Context, userId, id, revision int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert", ctx, userId, id, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert.
+func (mr *MockTodoItemMockRecorder) Revert(ctx, userId, id, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockTodoItem)(nil).Revert), ctx, userId, id, revision)
+}
+
+// Search mocks base method.
+func (m *MockTodoItem) Search(ctx context.Context, userId int, query todo_list_sber.SearchQuery) ([]todo_list_sber.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, userId, query)
+	ret0, _ := ret[0].([]todo_list_sber.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoItemMockRecorder) Search(ctx, userId, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoItem)(nil).Search), ctx, userId, query)
+}
+
+// Update mocks base method.
+func (m *MockTodoItem) Update(ctx context.Context, userId, id int, input todo_list_sber.UpdateItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, id, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTodoItemMockRecorder) Update(ctx, userId, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), ctx, userId, id, input)
+}
+
+// MockTodoList is a mock of TodoList interface.
+type MockTodoList struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoListMockRecorder
+}
+
+// MockTodoListMockRecorder is the mock recorder for MockTodoList.
+type MockTodoListMockRecorder struct {
+	mock *MockTodoList
+}
+
+// NewMockTodoList creates a new mock instance.
+func NewMockTodoList(ctrl *gomock.Controller) *MockTodoList {
+	mock := &MockTodoList{ctrl: ctrl}
+	mock.recorder = &MockTodoListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTodoList) Create(ctx context.Context, userId int, list todo_list_sber.TodoList) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, list)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTodoListMockRecorder) Create(ctx, userId, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoList)(nil).Create), ctx, userId, list)
+}
+
+// Delete mocks base method.
+func (m *MockTodoList) Delete(ctx context.Context, userId, id int, cascade bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id, cascade)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTodoListMockRecorder) Delete(ctx, userId, id, cascade interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), ctx, userId, id, cascade)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoList) GetAll(ctx context.Context, userId int) ([]todo_list_sber.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId)
+	ret0, _ := ret[0].([]todo_list_sber.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoListMockRecorder) GetAll(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUndoneTodoItems", reflect.TypeOf((*MockTodoItem)(nil).GetUndoneTodoItems), date, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockTodoList) GetById(ctx context.Context, userId, id int) (todo_list_sber.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
+	ret0, _ := ret[0].(todo_list_sber.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTodoListMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), ctx, userId, id)
 }
 
 // Update mocks base method.
-func (m *MockTodoItem) Update(id int, input todo_list_sber.UpdateItemInput) error {
+func (m *MockTodoList) Update(ctx context.Context, userId, id int, input todo_list_sber.UpdateListInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, input)
+	ret := m.ctrl.Call(m, "Update", ctx, userId, id, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoItemMockRecorder) Update(id, input interface{}) *gomock.Call {
+func (mr *MockTodoListMockRecorder) Update(ctx, userId, id, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), ctx, userId, id, input)
+}
+
+// MockTag is a mock of Tag interface.
+type MockTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagMockRecorder
+}
+
+// MockTagMockRecorder is the mock recorder for MockTag.
+type MockTagMockRecorder struct {
+	mock *MockTag
+}
+
+// NewMockTag creates a new mock instance.
+func NewMockTag(ctrl *gomock.Controller) *MockTag {
+	mock := &MockTag{ctrl: ctrl}
+	mock.recorder = &MockTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTag) EXPECT() *MockTagMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockTag) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockTagMockRecorder) Attach(ctx, userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockTag)(nil).Attach), ctx, userId, itemId, tagId)
+}
+
+// Create mocks base method.
+func (m *MockTag) Create(ctx context.Context, userId int, tag todo_list_sber.Tag) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagMockRecorder) Create(ctx, userId, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTag)(nil).Create), ctx, userId, tag)
+}
+
+// Delete mocks base method.
+func (m *MockTag) Delete(ctx context.Context, userId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagMockRecorder) Delete(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTag)(nil).Delete), ctx, userId, id)
+}
+
+// Detach mocks base method.
+func (m *MockTag) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", ctx, userId, itemId, tagId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockTagMockRecorder) Detach(ctx, userId, itemId, tagId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockTag)(nil).Detach), ctx, userId, itemId, tagId)
+}
+
+// GetAll mocks base method.
+func (m *MockTag) GetAll(ctx context.Context, userId int) ([]todo_list_sber.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId)
+	ret0, _ := ret[0].([]todo_list_sber.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTagMockRecorder) GetAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTag)(nil).GetAll), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockTag) GetById(ctx context.Context, userId, id int) (todo_list_sber.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
+	ret0, _ := ret[0].(todo_list_sber.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTagMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTag)(nil).GetById), ctx, userId, id)
+}
+
+// Update mocks base method.
+func (m *MockTag) Update(ctx context.Context, userId, id int, tag todo_list_sber.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, userId, id, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagMockRecorder) Update(ctx, userId, id, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTag)(nil).Update), ctx, userId, id, tag)
+}
+
+// MockReminder is a mock of Reminder interface.
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder.
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance.
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReminder) Create(ctx context.Context, userId, itemId int, reminder todo_list_sber.Reminder) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, itemId, reminder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReminderMockRecorder) Create(ctx, userId, itemId, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReminder)(nil).Create), ctx, userId, itemId, reminder)
+}
+
+// Delete mocks base method.
+func (m *MockReminder) Delete(ctx context.Context, userId, itemId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, itemId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReminderMockRecorder) Delete(ctx, userId, itemId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReminder)(nil).Delete), ctx, userId, itemId, id)
+}
+
+// GetByItem mocks base method.
+func (m *MockReminder) GetByItem(ctx context.Context, userId, itemId int) ([]todo_list_sber.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByItem", ctx, userId, itemId)
+	ret0, _ := ret[0].([]todo_list_sber.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByItem indicates an expected call of GetByItem.
+func (mr *MockReminderMockRecorder) GetByItem(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByItem", reflect.TypeOf((*MockReminder)(nil).GetByItem), ctx, userId, itemId)
+}
+
+// MockDependency is a mock of Dependency interface.
+type MockDependency struct {
+	ctrl     *gomock.Controller
+	recorder *MockDependencyMockRecorder
+}
+
+// MockDependencyMockRecorder is the mock recorder for MockDependency.
+type MockDependencyMockRecorder struct {
+	mock *MockDependency
+}
+
+// NewMockDependency creates a new mock instance.
+func NewMockDependency(ctrl *gomock.Controller) *MockDependency {
+	mock := &MockDependency{ctrl: ctrl}
+	mock.recorder = &MockDependencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDependency) EXPECT() *MockDependencyMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockDependency) Add(ctx context.Context, userId, itemId, blockerId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, userId, itemId, blockerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockDependencyMockRecorder) Add(ctx, userId, itemId, blockerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockDependency)(nil).Add), ctx, userId, itemId, blockerId)
+}
+
+// GetBlockers mocks base method.
+func (m *MockDependency) GetBlockers(ctx context.Context, userId, itemId int) ([]todo_list_sber.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockers", ctx, userId, itemId)
+	ret0, _ := ret[0].([]todo_list_sber.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockers indicates an expected call of GetBlockers.
+func (mr *MockDependencyMockRecorder) GetBlockers(ctx, userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockers", reflect.TypeOf((*MockDependency)(nil).GetBlockers), ctx, userId, itemId)
+}
+
+// Remove mocks base method.
+func (m *MockDependency) Remove(ctx context.Context, userId, itemId, blockerId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userId, itemId, blockerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockDependencyMockRecorder) Remove(ctx, userId, itemId, blockerId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockDependency)(nil).Remove), ctx, userId, itemId, blockerId)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhook) Create(ctx context.Context, userId int, webhook todo_list_sber.Webhook) (todo_list_sber.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userId, webhook)
+	ret0, _ := ret[0].(todo_list_sber.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookMockRecorder) Create(ctx, userId, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhook)(nil).Create), ctx, userId, webhook)
+}
+
+// Delete mocks base method.
+func (m *MockWebhook) Delete(ctx context.Context, userId, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookMockRecorder) Delete(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhook)(nil).Delete), ctx, userId, id)
+}
+
+// GetAll mocks base method.
+func (m *MockWebhook) GetAll(ctx context.Context, userId int) ([]todo_list_sber.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, userId)
+	ret0, _ := ret[0].([]todo_list_sber.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockWebhookMockRecorder) GetAll(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockWebhook)(nil).GetAll), ctx, userId)
+}
+
+// GetById mocks base method.
+func (m *MockWebhook) GetById(ctx context.Context, userId, id int) (todo_list_sber.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, userId, id)
+	ret0, _ := ret[0].(todo_list_sber.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockWebhookMockRecorder) GetById(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWebhook)(nil).GetById), ctx, userId, id)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhook) GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todo_list_sber.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, userId, webhookId, limit, offset)
+	ret0, _ := ret[0].([]todo_list_sber.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookMockRecorder) GetDeliveries(ctx, userId, webhookId, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhook)(nil).GetDeliveries), ctx, userId, webhookId, limit, offset)
+}
+
+// MockBatch is a mock of Batch interface.
+type MockBatch struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchMockRecorder
+}
+
+// MockBatchMockRecorder is the mock recorder for MockBatch.
+type MockBatchMockRecorder struct {
+	mock *MockBatch
+}
+
+// NewMockBatch creates a new mock instance.
+func NewMockBatch(ctrl *gomock.Controller) *MockBatch {
+	mock := &MockBatch{ctrl: ctrl}
+	mock.recorder = &MockBatchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatch) EXPECT() *MockBatchMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockBatch) Run(ctx context.Context, userId int, batch todo_list_sber.Batch) ([]todo_list_sber.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, userId, batch)
+	ret0, _ := ret[0].([]todo_list_sber.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockBatchMockRecorder) Run(ctx, userId, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockBatch)(nil).Run), ctx, userId, batch)
+}
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// Latest mocks base method.
+func (m *MockEvent) Latest(ctx context.Context, userId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Latest", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Latest indicates an expected call of Latest.
+func (mr *MockEventMockRecorder) Latest(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Latest", reflect.TypeOf((*MockEvent)(nil).Latest), ctx, userId)
+}
+
+// Since mocks base method.
+func (m *MockEvent) Since(ctx context.Context, userId, lastId, limit int) ([]todo_list_sber.StoredEvent, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Since", ctx, userId, lastId, limit)
+	ret0, _ := ret[0].([]todo_list_sber.StoredEvent)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Since indicates an expected call of Since.
+func (mr *MockEventMockRecorder) Since(ctx, userId, lastId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Since", reflect.TypeOf((*MockEvent)(nil).Since), ctx, userId, lastId, limit)
+}
+
+// Subscribe mocks base method.
+func (m *MockEvent) Subscribe(userId int) (<-chan struct{}, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userId)
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventMockRecorder) Subscribe(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), id, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEvent)(nil).Subscribe), userId)
 }
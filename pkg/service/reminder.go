@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+type ReminderService struct {
+	repo     repository.Reminder
+	itemRepo repository.TodoItem
+}
+
+func NewReminderService(repo repository.Reminder, itemRepo repository.TodoItem) *ReminderService {
+	return &ReminderService{repo: repo, itemRepo: itemRepo}
+}
+
+// Create schedules reminder against the current date of the item.
+func (s *ReminderService) Create(ctx context.Context, userId, itemId int, reminder todoListSber.Reminder) (int, error) {
+	if err := reminder.Validate(); err != nil {
+		return 0, err
+	}
+	item, err := s.itemRepo.GetById(ctx, userId, itemId)
+	if err != nil {
+		return 0, err
+	}
+	reminder.RemindAt = reminder.Schedule(item.Date).UTC()
+	return s.repo.Create(ctx, userId, itemId, reminder)
+}
+func (s *ReminderService) GetByItem(ctx context.Context, userId, itemId int) ([]todoListSber.Reminder, error) {
+	return s.repo.GetByItem(ctx, userId, itemId)
+}
+func (s *ReminderService) Delete(ctx context.Context, userId, itemId, id int) error {
+	return s.repo.Delete(ctx, userId, itemId, id)
+}
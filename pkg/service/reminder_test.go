@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestReminderServiceCreate(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewReminderService(repos.Reminder, repos.TodoItem)
+	date := time.Date(2024, time.June, 10, 18, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	itemId, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	before := 30
+	if _, err := s.Create(ctx, 1, itemId, todoListSber.Reminder{BeforeMinutes: &before}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	reminders, err := s.GetByItem(ctx, 1, itemId)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := date.Add(-30 * time.Minute); len(reminders) != 1 || !reminders[0].RemindAt.Equal(expected) {
+		t.Errorf("expected a reminder at %s; got %+v", expected, reminders)
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.Create(ctx, 1, itemId, todoListSber.Reminder{}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for an empty reminder; got %v", err)
+	}
+	var notFound *todoListSber.NotFoundError
+	if _, err := s.Create(ctx, 2, itemId, todoListSber.Reminder{BeforeMinutes: &before}); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError for another user; got %v", err)
+	}
+}
@@ -1,29 +1,115 @@
 package service
 
 import (
+	"context"
 	"time"
+	"todo-list-sber/pkg/config"
 	"todo-list-sber/pkg/repository"
 )
 import todoListSber "todo-list-sber"
 
 //go:generate mockgen -source=service.go -destination=mocks/mock.go
 
+type Authorization interface {
+	CreateUser(ctx context.Context, user todoListSber.User) (int, error)
+	GenerateToken(ctx context.Context, username, password string) (string, error)
+	ParseToken(accessToken string) (int, error)
+}
+
 type TodoItem interface {
-	Create(todoItem todoListSber.TodoItem) (int, error)
-	GetAll() ([]todoListSber.TodoItem, error)
-	GetById(id int) (todoListSber.TodoItem, error)
-	Delete(id int) error
-	Update(id int, input todoListSber.UpdateItemInput) error
-	GetDoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error)
-	GetUndoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error)
+	Create(ctx context.Context, userId int, todoItem todoListSber.TodoItem) (int, error)
+	GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error)
+	GetByCursor(ctx context.Context, userId int, query todoListSber.TodoItemQuery, cursor *todoListSber.ItemCursor) (todoListSber.TodoItemCursorPage, error)
+	Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error)
+	GetOccurrences(ctx context.Context, userId, id int, from, to time.Time, limit int) ([]time.Time, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error)
+	Delete(ctx context.Context, userId, id int) error
+	Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error
+	Replace(ctx context.Context, userId, id int, fields todoListSber.ItemFields) error
+	Patch(ctx context.Context, userId, id int, patch todoListSber.ItemPatch) error
+	GetChildren(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error)
+	GetTree(ctx context.Context, userId, id int) (todoListSber.TodoItemNode, error)
+	Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error
+	GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error)
+	Restore(ctx context.Context, userId, id int) error
+	GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error)
+	Revert(ctx context.Context, userId, id, revision int) error
+	GetDoneTodoItems(ctx context.Context, userId int, date *time.Time, limit int, offset int, filter todoListSber.TagFilter) ([]todoListSber.TodoItem, error)
+	GetUndoneTodoItems(ctx context.Context, userId int, date *time.Time, limit int, offset int, filter todoListSber.TagFilter) ([]todoListSber.TodoItem, error)
+	GetByList(ctx context.Context, userId, listId int, filter todoListSber.TagFilter, limit int, offset int) (todoListSber.TodoItemPage, error)
+}
+
+type TodoList interface {
+	Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error)
+	Delete(ctx context.Context, userId, id int, cascade bool) error
+	Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error
+}
+
+type Tag interface {
+	Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error)
+	Delete(ctx context.Context, userId, id int) error
+	Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error
+	Attach(ctx context.Context, userId, itemId, tagId int) error
+	Detach(ctx context.Context, userId, itemId, tagId int) error
+}
+
+type Reminder interface {
+	Create(ctx context.Context, userId, itemId int, reminder todoListSber.Reminder) (int, error)
+	GetByItem(ctx context.Context, userId, itemId int) ([]todoListSber.Reminder, error)
+	Delete(ctx context.Context, userId, itemId, id int) error
+}
+
+type Dependency interface {
+	GetBlockers(ctx context.Context, userId, itemId int) ([]todoListSber.TodoItem, error)
+	Add(ctx context.Context, userId, itemId, blockerId int) error
+	Remove(ctx context.Context, userId, itemId, blockerId int) error
+}
+
+type Webhook interface {
+	Create(ctx context.Context, userId int, webhook todoListSber.Webhook) (todoListSber.Webhook, error)
+	GetAll(ctx context.Context, userId int) ([]todoListSber.Webhook, error)
+	GetById(ctx context.Context, userId, id int) (todoListSber.Webhook, error)
+	Delete(ctx context.Context, userId, id int) error
+	GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todoListSber.WebhookDelivery, error)
+}
+
+type Batch interface {
+	Run(ctx context.Context, userId int, batch todoListSber.Batch) ([]todoListSber.BatchResult, error)
+}
+
+type Event interface {
+	Since(ctx context.Context, userId, lastId, limit int) ([]todoListSber.StoredEvent, bool, error)
+	Latest(ctx context.Context, userId int) (int, error)
+	Subscribe(userId int) (<-chan struct{}, func())
 }
 
 type Service struct {
+	Authorization
 	TodoItem
+	TodoList
+	Tag
+	Reminder
+	Dependency
+	Webhook
+	Batch
+	Event
 }
 
-func NewService(repos *repository.Repository) *Service {
+func NewService(repos *repository.Repository, authCfg config.AuthConfig, eventsCfg config.EventsConfig) *Service {
+	events := NewEventService(repos.EventLog, repos.Webhook, eventsCfg.BufferSize)
 	return &Service{
-		TodoItem: NewTodoItemService(repos.TodoItem),
+		Authorization: NewAuthService(repos.Authorization, []byte(authCfg.SigningKey), authCfg.TokenTTL),
+		TodoItem:      NewTodoItemService(repos, events),
+		TodoList:      NewTodoListService(repos.TodoList, repos.TodoItem, events),
+		Tag:           NewTagService(repos.Tag, repos.TodoItem, events),
+		Reminder:      NewReminderService(repos.Reminder, repos.TodoItem),
+		Dependency:    NewDependencyService(repos.Dependency),
+		Webhook:       NewWebhookService(repos.Webhook),
+		Batch:         NewBatchService(repos, events),
+		Event:         events,
 	}
 }
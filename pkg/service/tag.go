@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// TagService publishes item.updated for every item whose tags change.
+type TagService struct {
+	repo     repository.Tag
+	itemRepo repository.TodoItem
+	events   *EventService
+}
+
+func NewTagService(repo repository.Tag, itemRepo repository.TodoItem, events *EventService) *TagService {
+	return &TagService{repo: repo, itemRepo: itemRepo, events: events}
+}
+func (s *TagService) Create(ctx context.Context, userId int, tag todoListSber.Tag) (int, error) {
+	if err := tag.Validate(); err != nil {
+		return 0, err
+	}
+	return s.repo.Create(ctx, userId, tag)
+}
+func (s *TagService) GetAll(ctx context.Context, userId int) ([]todoListSber.Tag, error) {
+	return s.repo.GetAll(ctx, userId)
+}
+func (s *TagService) GetById(ctx context.Context, userId, id int) (todoListSber.Tag, error) {
+	return s.repo.GetById(ctx, userId, id)
+}
+func (s *TagService) Delete(ctx context.Context, userId, id int) error {
+	ids, err := s.taggedItems(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userId, id); err != nil {
+		return err
+	}
+	s.events.publishUpdated(ctx, s.itemRepo, userId, ids)
+	return nil
+}
+func (s *TagService) Update(ctx context.Context, userId, id int, tag todoListSber.Tag) error {
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+	ids, err := s.taggedItems(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, userId, id, tag); err != nil {
+		return err
+	}
+	s.events.publishUpdated(ctx, s.itemRepo, userId, ids)
+	return nil
+}
+func (s *TagService) Attach(ctx context.Context, userId, itemId, tagId int) error {
+	if err := s.repo.Attach(ctx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	s.events.publishUpdated(ctx, s.itemRepo, userId, []int{itemId})
+	return nil
+}
+func (s *TagService) Detach(ctx context.Context, userId, itemId, tagId int) error {
+	if err := s.repo.Detach(ctx, userId, itemId, tagId); err != nil {
+		return err
+	}
+	s.events.publishUpdated(ctx, s.itemRepo, userId, []int{itemId})
+	return nil
+}
+
+// taggedItems returns the ids of the items carrying tag id.
+func (s *TagService) taggedItems(ctx context.Context, userId, id int) ([]int, error) {
+	tag, err := s.repo.GetById(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	filter := todoListSber.TagFilter{Tags: []string{tag.Name}}
+	page, err := s.itemRepo.GetAll(ctx, userId, todoListSber.TodoItemQuery{Tags: filter, SkipTotal: true})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(page.Items))
+	for i, item := range page.Items {
+		ids[i] = item.Id
+	}
+	return ids, nil
+}
@@ -1,37 +1,447 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"time"
 	todoListSber "todo-list-sber"
 	"todo-list-sber/pkg/repository"
 )
 
+// TodoItemService publishes an event for every item it creates, updates, completes or deletes.
+// It refuses to mark an item done while items blocking it are open.
 type TodoItemService struct {
-	repo repository.TodoItem
+	repo           repository.TodoItem
+	listRepo       repository.TodoList
+	dependencyRepo repository.Dependency
+	transactor     repository.Transactor
+	events         *EventService
 }
 
-func NewTodoItemService(repo repository.TodoItem) *TodoItemService {
-	return &TodoItemService{repo: repo}
+func NewTodoItemService(repos *repository.Repository, events *EventService) *TodoItemService {
+	return &TodoItemService{repo: repos.TodoItem, listRepo: repos.TodoList, dependencyRepo: repos.Dependency, transactor: repos, events: events}
 }
-func (s *TodoItemService) Create(item todoListSber.TodoItem) (int, error) {
-	return s.repo.Create(item)
+
+// transaction runs fn with a TodoItemService working on the repositories of a single transaction.
+// The events fn publishes are recorded once the transaction has committed.
+func (s *TodoItemService) transaction(ctx context.Context, fn func(tx *TodoItemService) error) error {
+	held := s.events.hold()
+	err := s.transactor.Transaction(ctx, func(repos *repository.Repository) error {
+		return fn(NewTodoItemService(repos, held))
+	})
+	if err != nil {
+		return err
+	}
+	s.events.release(ctx, held)
+	return nil
+}
+func (s *TodoItemService) Create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error) {
+	if err := item.Validate(); err != nil {
+		return 0, err
+	}
+	if err := s.checkList(ctx, userId, item.ListId); err != nil {
+		return 0, err
+	}
+	if item.ParentId != nil {
+		if _, err := s.repo.GetById(ctx, userId, *item.ParentId); err != nil {
+			return 0, err
+		}
+	}
+	if item.Priority == 0 {
+		item.Priority = todoListSber.DefaultPriority
+	}
+	return s.create(ctx, userId, item)
+}
+func (s *TodoItemService) GetAll(ctx context.Context, userId int, query todoListSber.TodoItemQuery) (todoListSber.TodoItemPage, error) {
+	if err := query.Validate(); err != nil {
+		return todoListSber.TodoItemPage{}, err
+	}
+	return s.repo.GetAll(ctx, userId, query)
+}
+
+// GetByCursor returns a keyset page of query ordered by (date, id), starting after cursor or at the
+// beginning when cursor is nil. Only the filters, Desc and Limit of query are used; a cursor keeps
+// the direction of the listing it was taken from.
+func (s *TodoItemService) GetByCursor(ctx context.Context, userId int, query todoListSber.TodoItemQuery, cursor *todoListSber.ItemCursor) (todoListSber.TodoItemCursorPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		return todoListSber.TodoItemCursorPage{}, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	if cursor != nil {
+		query.Desc = cursor.Desc
+	}
+	desc := query.Desc
+	backward := cursor != nil && cursor.Backward
+
+	// One extra item tells whether there is another page in the direction of travel.
+	query.Sort = todoListSber.SortByDate
+	query.Offset = 0
+	query.Limit = limit + 1
+	query.After = cursor
+	query.SkipTotal = true
+	if backward {
+		query.Desc = !desc
+	}
+	page, err := s.GetAll(ctx, userId, query)
+	if err != nil {
+		return todoListSber.TodoItemCursorPage{}, err
+	}
+
+	items := page.Items
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	result := todoListSber.TodoItemCursorPage{Items: items}
+	if len(items) == 0 {
+		return result, nil
+	}
+	if hasMore || backward {
+		last := items[len(items)-1]
+		result.Next = &todoListSber.ItemCursor{Date: last.Date, Id: last.Id, Desc: desc}
+	}
+	if hasMore && backward || !backward && cursor != nil {
+		first := items[0]
+		result.Prev = &todoListSber.ItemCursor{Date: first.Date, Id: first.Id, Desc: desc, Backward: true}
+	}
+	return result, nil
+}
+func (s *TodoItemService) Search(ctx context.Context, userId int, query todoListSber.SearchQuery) ([]todoListSber.SearchResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Search(ctx, userId, query)
+}
+
+// GetById returns the item with the counts of its subtasks.
+func (s *TodoItemService) GetById(ctx context.Context, userId, id int) (todoListSber.TodoItem, error) {
+	tree, err := s.GetTree(ctx, userId, id)
+	return tree.TodoItem, err
+}
+
+// GetChildren returns the direct subtasks of the item ordered by date, each with the counts of
+// its own subtasks.
+func (s *TodoItemService) GetChildren(ctx context.Context, userId, id int) ([]todoListSber.TodoItem, error) {
+	tree, err := s.GetTree(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	children := make([]todoListSber.TodoItem, len(tree.Children))
+	for i, child := range tree.Children {
+		children[i] = child.TodoItem
+	}
+	return children, nil
+}
+
+// GetTree returns the item with its subtasks at any depth.
+func (s *TodoItemService) GetTree(ctx context.Context, userId, id int) (todoListSber.TodoItemNode, error) {
+	subtree, err := s.repo.GetSubtree(ctx, userId, id)
+	if err != nil {
+		return todoListSber.TodoItemNode{}, err
+	}
+	return todoListSber.BuildTree(subtree), nil
+}
+
+// Move puts the item together with its subtasks under input.ParentId.
+func (s *TodoItemService) Move(ctx context.Context, userId, id int, input todoListSber.MoveItemInput) error {
+	if err := s.repo.Move(ctx, userId, id, input); err != nil {
+		return err
+	}
+	s.events.publishUpdated(ctx, s.repo, userId, []int{id})
+	return nil
+}
+
+// Delete moves the item together with its subtasks to the trash.
+func (s *TodoItemService) Delete(ctx context.Context, userId, id int) error {
+	subtree, err := s.repo.GetSubtree(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userId, id); err != nil {
+		return err
+	}
+	for _, item := range subtree {
+		s.events.Publish(ctx, userId, todoListSber.EventItemDeleted, item)
+	}
+	return nil
+}
+
+// GetTrash returns a page of the deleted items, the most recently deleted first.
+func (s *TodoItemService) GetTrash(ctx context.Context, userId, limit, offset int) ([]todoListSber.TodoItem, error) {
+	if limit <= 0 {
+		return nil, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	if offset < 0 {
+		return nil, &todoListSber.ValidationError{Message: "offset must not be negative"}
+	}
+	return s.repo.GetTrash(ctx, userId, limit, offset)
+}
+
+// Restore takes the item out of the trash together with the subtasks deleted with it.
+func (s *TodoItemService) Restore(ctx context.Context, userId, id int) error {
+	if err := s.repo.Restore(ctx, userId, id); err != nil {
+		return err
+	}
+	subtree, err := s.repo.GetSubtree(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	for _, item := range subtree {
+		s.events.Publish(ctx, userId, todoListSber.EventItemRestored, item)
+	}
+	return nil
+}
+
+// GetHistory returns the revisions recorded by the updates of the item, the latest first.
+func (s *TodoItemService) GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error) {
+	return s.repo.GetHistory(ctx, userId, id)
+}
+
+// Revert brings the item back to its state right after revision, or as it was created for revision
+// 0. It is made of an ordinary move and update, so it is checked like them and recorded as new
+// revisions, in one transaction that fails with ErrVersionMismatch should the item change meanwhile.
+func (s *TodoItemService) Revert(ctx context.Context, userId, id, revision int) error {
+	return s.transaction(ctx, func(tx *TodoItemService) error {
+		item, err := tx.repo.GetById(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		revisions, err := tx.repo.GetHistory(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		latest := 0
+		if len(revisions) > 0 {
+			latest = revisions[0].Revision
+		}
+		if revision < 0 || revision > latest {
+			return todoListSber.ErrRevisionNotFound(id, revision)
+		}
+		plan, err := todoListSber.PlanRevert(item, revisions, revision)
+		if err != nil {
+			return err
+		}
+		version := item.Version
+		if plan.Move != nil {
+			plan.Move.Version = &version
+			if err := tx.Move(ctx, userId, id, *plan.Move); err != nil {
+				return err
+			}
+			version++
+		}
+		if plan.Input != nil {
+			plan.Input.Version = &version
+			return tx.Update(ctx, userId, id, *plan.Input)
+		}
+		return nil
+	})
+}
+
+// Replace sets every field of the item to fields, see ItemFields.
+func (s *TodoItemService) Replace(ctx context.Context, userId, id int, fields todoListSber.ItemFields) error {
+	if err := fields.Validate(); err != nil {
+		return err
+	}
+	return s.Update(ctx, userId, id, fields.UpdateInput())
+}
+
+// maxPatchAttempts bounds how often a patch without a version is reapplied to an item changed by
+// someone else while it was being patched.
+const maxPatchAttempts = 3
+
+// Patch applies patch to the fields of the item and replaces them with the result. Without
+// patch.Version the patch is applied to the version of the item it read, and to the next version
+// should the item change in between.
+func (s *TodoItemService) Patch(ctx context.Context, userId, id int, patch todoListSber.ItemPatch) error {
+	for attempt := 1; ; attempt++ {
+		item, err := s.repo.GetById(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		if patch.Version != nil && *patch.Version != item.Version {
+			return todoListSber.ErrVersionMismatch(id, item.Version)
+		}
+		fields := todoListSber.FieldsOf(item)
+		fields.Cascade, fields.Version = patch.Cascade, &item.Version
+		if fields, err = patch.Apply(fields); err != nil {
+			return err
+		}
+		err = s.Replace(ctx, userId, id, fields)
+		var mismatch *todoListSber.PreconditionFailedError
+		if patch.Version == nil && errors.As(err, &mismatch) && attempt < maxPatchAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// Update marks the undone subtasks of the item done as well when input.Cascade is set.
+func (s *TodoItemService) Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkList(ctx, userId, input.ListId); err != nil {
+		return err
+	}
+	if input.IsDone == nil || !*input.IsDone {
+		return s.update(ctx, userId, id, input)
+	}
+
+	// The items to complete are checked up front, so that a cascade is refused as a whole, and
+	// completed in one transaction, so that it does not stop halfway either.
+	return s.transaction(ctx, func(tx *TodoItemService) error {
+		completing, err := tx.repo.GetSubtree(ctx, userId, id)
+		if err != nil {
+			return err
+		}
+		if !input.Cascade {
+			completing = completing[:1]
+		}
+		if err := tx.checkBlockers(ctx, userId, completing); err != nil {
+			return err
+		}
+		if err := tx.update(ctx, userId, id, input); err != nil {
+			return err
+		}
+		isDone := true
+		for _, subtask := range completing[1:] {
+			if subtask.IsDone {
+				continue
+			}
+			if err := tx.update(ctx, userId, subtask.Id, todoListSber.UpdateItemInput{IsDone: &isDone}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetOccurrences expands the item over the days from and to, taken in the timezone of the item.
+func (s *TodoItemService) GetOccurrences(ctx context.Context, userId, id int, from, to time.Time, limit int) ([]time.Time, error) {
+	if to.Before(from) {
+		return nil, &todoListSber.ValidationError{Message: "to must not be before from"}
+	}
+	if limit <= 0 {
+		return nil, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	item, err := s.repo.GetById(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	loc := item.Location()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, -1, loc)
+	return item.Occurrences(start, end, limit)
 }
-func (s *TodoItemService) GetAll() ([]todoListSber.TodoItem, error) {
-	return s.repo.GetAll()
+func (s *TodoItemService) GetDoneTodoItems(ctx context.Context, userId int, date *time.Time, limit int, offset int, filter todoListSber.TagFilter) ([]todoListSber.TodoItem, error) {
+	return s.getByStatus(ctx, userId, true, date, limit, offset, filter)
 }
-func (s *TodoItemService) GetById(id int) (todoListSber.TodoItem, error) {
-	return s.repo.GetById(id)
+func (s *TodoItemService) GetUndoneTodoItems(ctx context.Context, userId int, date *time.Time, limit int, offset int, filter todoListSber.TagFilter) ([]todoListSber.TodoItem, error) {
+	return s.getByStatus(ctx, userId, false, date, limit, offset, filter)
 }
-func (s *TodoItemService) Delete(id int) error {
-	return s.repo.Delete(id)
+func (s *TodoItemService) GetByList(ctx context.Context, userId, listId int, filter todoListSber.TagFilter, limit int, offset int) (todoListSber.TodoItemPage, error) {
+	if limit <= 0 {
+		return todoListSber.TodoItemPage{}, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	if err := s.checkList(ctx, userId, &listId); err != nil {
+		return todoListSber.TodoItemPage{}, err
+	}
+	return s.GetAll(ctx, userId, todoListSber.TodoItemQuery{ListId: &listId, Tags: filter, Limit: limit, Offset: offset})
 }
-func (s *TodoItemService) Update(id int, input todoListSber.UpdateItemInput) error {
 
-	return s.repo.Update(id, input)
+func (s *TodoItemService) getByStatus(ctx context.Context, userId int, isDone bool, date *time.Time, limit int, offset int, filter todoListSber.TagFilter) ([]todoListSber.TodoItem, error) {
+	query := todoListSber.TodoItemQuery{IsDone: &isDone, From: date, To: date, Tags: filter, Limit: limit, Offset: offset}
+	page, err := s.repo.GetAll(ctx, userId, query)
+	return page.Items, err
 }
-func (s *TodoItemService) GetDoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error) {
-	return s.repo.GetDoneTodoItems(date, limit, offset)
+
+func (s *TodoItemService) update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	before, err := s.repo.GetById(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, userId, id, input); err != nil {
+		return err
+	}
+	item, err := s.repo.GetById(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+	s.events.Publish(ctx, userId, todoListSber.EventItemUpdated, item)
+	if before.IsDone || !item.IsDone {
+		return nil
+	}
+	s.events.Publish(ctx, userId, todoListSber.EventItemCompleted, item)
+
+	// Marking an occurrence of a recurring item done creates the next occurrence, once: the item
+	// keeps a link to it, so marking it undone and done again does not create another one.
+	if item.NextId != nil {
+		return nil
+	}
+	next, ok, err := item.NextOccurrence()
+	if err != nil || !ok {
+		return err
+	}
+	nextId, err := s.create(ctx, userId, next)
+	if err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, userId, id, todoListSber.UpdateItemInput{NextId: &nextId})
 }
-func (s *TodoItemService) GetUndoneTodoItems(date *time.Time, limit int, offset int) ([]todoListSber.TodoItem, error) {
-	return s.repo.GetUndoneTodoItems(date, limit, offset)
+
+func (s *TodoItemService) create(ctx context.Context, userId int, item todoListSber.TodoItem) (int, error) {
+	id, err := s.repo.Create(ctx, userId, item)
+	if err != nil {
+		return 0, err
+	}
+	if created, err := s.repo.GetById(ctx, userId, id); err != nil {
+		slog.Error("error loading created item for its event", "item_id", id, "error", err)
+	} else {
+		s.events.Publish(ctx, userId, todoListSber.EventItemCreated, created)
+	}
+	return id, nil
+}
+
+// checkBlockers refuses to complete the undone items while they are blocked by open items other
+// than the ones being completed with them.
+func (s *TodoItemService) checkBlockers(ctx context.Context, userId int, items []todoListSber.TodoItem) error {
+	completing := make(map[int]bool, len(items))
+	for _, item := range items {
+		completing[item.Id] = true
+	}
+	for _, item := range items {
+		if item.IsDone {
+			continue
+		}
+		blockers, err := s.dependencyRepo.GetBlockers(ctx, userId, item.Id)
+		if err != nil {
+			return err
+		}
+		var open []int
+		for _, blocker := range blockers {
+			if !blocker.IsDone && !completing[blocker.Id] {
+				open = append(open, blocker.Id)
+			}
+		}
+		if len(open) > 0 {
+			return todoListSber.ErrItemBlocked(item.Id, open)
+		}
+	}
+	return nil
+}
+
+// checkList makes sure an item is only put into a list of its owner.
+func (s *TodoItemService) checkList(ctx context.Context, userId int, listId *int) error {
+	if listId == nil {
+		return nil
+	}
+	_, err := s.listRepo.GetById(ctx, userId, *listId)
+	return err
 }
@@ -0,0 +1,685 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestTodoItemServicePriority(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: time.Now()})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item, err := s.GetById(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item.Priority != todoListSber.DefaultPriority {
+		t.Errorf("expected default priority %d; got %d", todoListSber.DefaultPriority, item.Priority)
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: time.Now(), Priority: 5}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for priority 5; got %v", err)
+	}
+	priority := 0
+	if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{Priority: &priority}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for priority 0; got %v", err)
+	}
+}
+
+func TestTodoItemServiceGetAllValidation(t *testing.T) {
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	from := time.Date(2024, time.June, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	queries := map[string]todoListSber.TodoItemQuery{
+		"Unknown Sort":   {Sort: "color"},
+		"Reversed Range": {From: &from, To: &to},
+	}
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			var validation *todoListSber.ValidationError
+			if _, err := s.GetAll(context.Background(), 1, query); !errors.As(err, &validation) {
+				t.Errorf("expected ValidationError; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemServiceRecurrence(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	// 09:00 in Moscow on Monday, June 3rd.
+	date := time.Date(2024, time.June, 3, 6, 0, 0, 0, time.UTC)
+
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{
+		Title:      "Standup",
+		Date:       date,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3",
+		Timezone:   "Europe/Moscow",
+		Tags:       []string{"team"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	occurrences, err := s.GetOccurrences(ctx, 1, id, date, date.AddDate(0, 0, 30), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var got []string
+	for _, occurrence := range occurrences {
+		got = append(got, occurrence.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	if expected := []string{"Mon 2024-06-03 09:00 MSK", "Thu 2024-06-06 09:00 MSK", "Mon 2024-06-10 09:00 MSK"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected occurrences %v; got %v", expected, got)
+	}
+
+	done := true
+	for i := 0; i < 3; i++ {
+		if err := s.Update(ctx, 1, id+i, todoListSber.UpdateItemInput{IsDone: &done}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	// Marking an item done again must not create another occurrence.
+	if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{IsDone: &done}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	page, err := s.GetAll(ctx, 1, todoListSber.TodoItemQuery{Sort: todoListSber.SortById})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page.Items) != 3 {
+		t.Fatalf("expected the series to end after 3 items; got %d", len(page.Items))
+	}
+	second := page.Items[1]
+	if !second.Date.Equal(time.Date(2024, time.June, 6, 6, 0, 0, 0, time.UTC)) || second.Recurrence != "FREQ=WEEKLY;COUNT=2;BYDAY=MO,TH" ||
+		!reflect.DeepEqual(second.Tags, []string{"team"}) {
+		t.Errorf("unexpected next occurrence %+v", second)
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date, Recurrence: "FREQ=HOURLY"}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for an unsupported rule; got %v", err)
+	}
+	if _, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date, Timezone: "Mars/Olympus"}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for an unknown timezone; got %v", err)
+	}
+}
+
+func TestTodoItemServiceRecurrenceToggle(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	date := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Report", Date: date, Recurrence: "FREQ=WEEKLY;BYDAY=MO"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Marking the item done twice, undoing it in between, creates a single next occurrence.
+	for _, isDone := range []bool{true, false, true, false, true} {
+		if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{IsDone: &isDone}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	page, err := s.GetAll(ctx, 1, todoListSber.TodoItemQuery{Sort: todoListSber.SortById})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("expected the item and one next occurrence; got %+v", page.Items)
+	}
+	next := page.Items[1]
+	if !next.Date.Equal(date.AddDate(0, 0, 7)) || next.NextId != nil {
+		t.Errorf("unexpected next occurrence %+v", next)
+	}
+	if item := page.Items[0]; item.NextId == nil || *item.NextId != next.Id {
+		t.Errorf("expected the item linked to occurrence %d; got %+v", next.Id, item)
+	}
+}
+
+func TestTodoItemServiceSearchValidation(t *testing.T) {
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+
+	queries := map[string]todoListSber.SearchQuery{
+		"Blank Text":       {Text: "  "},
+		"Unknown Language": {Text: "milk", Language: "german"},
+		"Negative Offset":  {Text: "milk", Offset: -1},
+	}
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			var validation *todoListSber.ValidationError
+			if _, err := s.Search(context.Background(), 1, query); !errors.As(err, &validation) {
+				t.Errorf("expected ValidationError; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemServiceGetByCursor(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	day := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	// Items 1..5 share a date so that the pages are split by id alone.
+	for i := 0; i < 7; i++ {
+		date := day
+		if i >= 5 {
+			date = day.AddDate(0, 0, 1)
+		}
+		if _, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Task", Date: date}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	ids := func(page todoListSber.TodoItemCursorPage) []int {
+		var result []int
+		for _, item := range page.Items {
+			result = append(result, item.Id)
+		}
+		return result
+	}
+	get := func(desc bool, cursor *todoListSber.ItemCursor) todoListSber.TodoItemCursorPage {
+		t.Helper()
+		page, err := s.GetByCursor(ctx, 1, todoListSber.TodoItemQuery{Limit: 3, Desc: desc}, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return page
+	}
+
+	first := get(false, nil)
+	if !reflect.DeepEqual(ids(first), []int{1, 2, 3}) || first.Prev != nil || first.Next == nil {
+		t.Fatalf("unexpected first page %v, prev %v, next %v", ids(first), first.Prev, first.Next)
+	}
+	second := get(false, first.Next)
+	if !reflect.DeepEqual(ids(second), []int{4, 5, 6}) || second.Prev == nil || second.Next == nil {
+		t.Fatalf("unexpected second page %v, prev %v, next %v", ids(second), second.Prev, second.Next)
+	}
+	last := get(false, second.Next)
+	if !reflect.DeepEqual(ids(last), []int{7}) || last.Next != nil {
+		t.Fatalf("unexpected last page %v, next %v", ids(last), last.Next)
+	}
+	back := get(false, last.Prev)
+	if !reflect.DeepEqual(ids(back), []int{4, 5, 6}) || back.Prev == nil || back.Next == nil {
+		t.Fatalf("unexpected page before the last %v, prev %v, next %v", ids(back), back.Prev, back.Next)
+	}
+	start := get(false, back.Prev)
+	if !reflect.DeepEqual(ids(start), []int{1, 2, 3}) || start.Prev != nil {
+		t.Fatalf("unexpected page before the second %v, prev %v", ids(start), start.Prev)
+	}
+
+	// The cursor keeps the direction of the listing it was taken from.
+	desc := get(true, nil)
+	if !reflect.DeepEqual(ids(desc), []int{7, 6, 5}) {
+		t.Fatalf("unexpected desc page %v", ids(desc))
+	}
+	if next := get(false, desc.Next); !reflect.DeepEqual(ids(next), []int{4, 3, 2}) {
+		t.Errorf("unexpected second desc page %v", ids(next))
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.GetByCursor(ctx, 1, todoListSber.TodoItemQuery{}, nil); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for zero limit; got %v", err)
+	}
+}
+
+func TestTodoItemServiceSubtasks(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(repos.EventLog, repos.Webhook, 100)
+	s := NewTodoItemService(repos, events)
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	create := func(title string, parentId *int, isDone bool) int {
+		id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: title, Date: date, ParentId: parentId, IsDone: isDone})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return id
+	}
+	root := create("Root", nil, false)
+	child := create("Child", &root, false)
+	create("Done child", &root, true)
+	grandchild := create("Grandchild", &child, false)
+
+	var notFound *todoListSber.NotFoundError
+	if _, err := s.Create(ctx, 2, todoListSber.TodoItem{Title: "Foreign", Date: date, ParentId: &root}); !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError for a parent of another user; got %v", err)
+	}
+
+	item, err := s.GetById(ctx, 1, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := (todoListSber.SubtaskCounts{Completed: 1, Total: 3}); item.Subtasks == nil || *item.Subtasks != expected {
+		t.Errorf("expected counts %+v; got %+v", expected, item.Subtasks)
+	}
+	children, err := s.GetChildren(ctx, 1, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(children) != 2 || children[0].Id != child || children[0].Subtasks == nil || children[0].Subtasks.Total != 1 || children[1].Subtasks != nil {
+		t.Errorf("expected the two children with their own counts; got %+v", children)
+	}
+
+	isDone := true
+	if err := s.Update(ctx, 1, root, todoListSber.UpdateItemInput{IsDone: &isDone, Cascade: true}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tree, err := s.GetTree(ctx, 1, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := (todoListSber.SubtaskCounts{Completed: 3, Total: 3}); !tree.IsDone || *tree.Subtasks != expected {
+		t.Errorf("expected the whole tree to be done; got %+v", tree)
+	}
+	if tree.Children[0].Children[0].Id != grandchild || !tree.Children[0].Children[0].IsDone {
+		t.Errorf("expected the grandchild to be done; got %+v", tree.Children[0].Children)
+	}
+
+	if err := s.Move(ctx, 1, root, todoListSber.MoveItemInput{ParentId: &grandchild}); !errors.Is(err, todoListSber.ErrItemCycle) {
+		t.Errorf("expected ErrItemCycle; got %v", err)
+	}
+	latest, err := events.Latest(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Delete(ctx, 1, root); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	deleted, _, err := events.Since(ctx, 1, latest, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(deleted) != 4 {
+		t.Errorf("expected an event for every deleted item; got %d", len(deleted))
+	}
+}
+
+// failingUpdates fails the updates of the item failId.
+type failingUpdates struct {
+	repository.TodoItem
+	failId int
+}
+
+func (r failingUpdates) Update(ctx context.Context, userId, id int, input todoListSber.UpdateItemInput) error {
+	if id == r.failId {
+		return errors.New("update failed")
+	}
+	return r.TodoItem.Update(ctx, userId, id, input)
+}
+
+// wrappedTransactions hands the transactions of Transactor repositories whose TodoItem is wrapped
+// by wrap.
+type wrappedTransactions struct {
+	repository.Transactor
+	wrap func(repository.TodoItem) repository.TodoItem
+}
+
+func (t wrappedTransactions) Transaction(ctx context.Context, fn func(repos *repository.Repository) error) error {
+	return t.Transactor.Transaction(ctx, func(repos *repository.Repository) error {
+		wrapped := *repos
+		wrapped.TodoItem = t.wrap(repos.TodoItem)
+		return fn(&wrapped)
+	})
+}
+
+func TestTodoItemServiceCascadeRollback(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(repos.EventLog, repos.Webhook, 100)
+	s := NewTodoItemService(repos, events)
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	root, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Root", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	first, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "First", Date: date, ParentId: &root})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Second", Date: date, ParentId: &root})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	latest, err := events.Latest(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	repos.Transactor = wrappedTransactions{Transactor: repos.Transactor, wrap: func(r repository.TodoItem) repository.TodoItem {
+		return failingUpdates{TodoItem: r, failId: second}
+	}}
+	isDone := true
+	if err := s.Update(ctx, 1, root, todoListSber.UpdateItemInput{IsDone: &isDone, Cascade: true}); err == nil {
+		t.Fatal("expected the cascade to fail")
+	}
+	for _, id := range []int{root, first, second} {
+		item, err := s.GetById(ctx, 1, id)
+		if err != nil || item.IsDone {
+			t.Errorf("expected item %d left undone; got %+v, %v", id, item, err)
+		}
+	}
+	if published, _, err := events.Since(ctx, 1, latest, 10); err != nil || len(published) != 0 {
+		t.Errorf("expected no events of a failed cascade; got %d, %v", len(published), err)
+	}
+}
+
+func TestTodoItemServiceBlockers(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	create := func(title string, parentId *int) int {
+		id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: title, Date: date, ParentId: parentId})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return id
+	}
+	release := create("Release", nil)
+	tests := create("Tests", &release)
+	build := create("Build", &release)
+	docs := create("Docs", nil)
+	for _, dependency := range [][2]int{{tests, build}, {release, docs}} {
+		if err := repos.Dependency.Add(ctx, 1, dependency[0], dependency[1]); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	isDone := true
+	var conflict *todoListSber.ConflictError
+	err := s.Update(ctx, 1, tests, todoListSber.UpdateItemInput{IsDone: &isDone})
+	if !errors.As(err, &conflict) || err.Error() != "todo item 2 is blocked by open items 3" {
+		t.Errorf("expected ConflictError for the blocked item; got %v", err)
+	}
+	if err := s.Update(ctx, 1, release, todoListSber.UpdateItemInput{IsDone: &isDone, Cascade: true}); !errors.As(err, &conflict) {
+		t.Errorf("expected ConflictError for the blocked release; got %v", err)
+	}
+	if item, _ := s.GetById(ctx, 1, build); item.IsDone {
+		t.Error("expected a refused cascade to leave the subtasks open")
+	}
+
+	if err := s.Update(ctx, 1, docs, todoListSber.UpdateItemInput{IsDone: &isDone}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Tests is blocked by Build, which the cascade completes too.
+	if err := s.Update(ctx, 1, release, todoListSber.UpdateItemInput{IsDone: &isDone, Cascade: true}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item, err := s.GetById(ctx, 1, release)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := (todoListSber.SubtaskCounts{Completed: 2, Total: 2}); !item.IsDone || *item.Subtasks != expected {
+		t.Errorf("expected the release and its subtasks to be done; got %+v", item)
+	}
+}
+
+func TestTodoItemServiceTrash(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	events := NewEventService(repos.EventLog, repos.Webhook, 100)
+	s := NewTodoItemService(repos, events)
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	root, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Root", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Child", Date: date, ParentId: &root}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Delete(ctx, 1, root); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var validation *todoListSber.ValidationError
+	if _, err := s.GetTrash(ctx, 1, 0, 0); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError for a zero limit; got %v", err)
+	}
+	trash, err := s.GetTrash(ctx, 1, 10, 0)
+	if err != nil || len(trash) != 2 {
+		t.Fatalf("expected both items in the trash; got %v, %v", trash, err)
+	}
+
+	latest, err := events.Latest(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Restore(ctx, 1, root); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	restored, _, err := events.Since(ctx, 1, latest, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(restored) != 2 || restored[0].Event != todoListSber.EventItemRestored {
+		t.Errorf("expected an item.restored event for every restored item; got %+v", restored)
+	}
+	item, err := s.GetById(ctx, 1, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item.DeletedAt != nil || item.Subtasks == nil || item.Subtasks.Total != 1 {
+		t.Errorf("expected the item to be back with its subtask; got %+v", item)
+	}
+}
+
+func TestTodoItemServiceRevert(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Report", Date: date, Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	title, later, isDone := "Quarterly report", date.AddDate(0, 0, 7), true
+	for _, input := range []todoListSber.UpdateItemInput{
+		{Title: &title},
+		{Date: &later, Tags: &[]string{}},
+		{IsDone: &isDone},
+	} {
+		if err := s.Update(ctx, 1, id, input); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var notFound *todoListSber.NotFoundError
+	for _, revision := range []int{-1, 4} {
+		if err := s.Revert(ctx, 1, id, revision); !errors.As(err, &notFound) {
+			t.Errorf("expected NotFoundError for revision %d; got %v", revision, err)
+		}
+	}
+
+	if err := s.Revert(ctx, 1, id, 1); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item, err := s.GetById(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item.Title != title || !item.Date.Equal(date) || item.IsDone || len(item.Tags) != 1 {
+		t.Errorf("expected the state right after the first revision; got %+v", item)
+	}
+	history, err := s.GetHistory(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(history) != 4 || len(history[0].Changes) != 3 {
+		t.Errorf("expected the revert to be recorded as a revision of three fields; got %+v", history)
+	}
+
+	if err := s.Revert(ctx, 1, id, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err = s.GetById(ctx, 1, id); err != nil || item.Title != "Report" {
+		t.Errorf("expected the item as it was created; got %+v, %v", item, err)
+	}
+
+	// Reverting a move into a list takes the item out of it again.
+	listId, err := repos.TodoList.Create(ctx, 1, todoListSber.TodoList{Title: "Work"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{ListId: &listId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Revert(ctx, 1, id, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err = s.GetById(ctx, 1, id); err != nil || item.ListId != nil {
+		t.Errorf("expected the item out of the list; got %+v, %v", item, err)
+	}
+
+	// Reverting a move puts the item back under its former parent.
+	parent, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Parent", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Move(ctx, 1, id, todoListSber.MoveItemInput{ParentId: &parent}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	history, err = s.GetHistory(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Revert(ctx, 1, id, history[1].Revision); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err = s.GetById(ctx, 1, id); err != nil || item.ParentId != nil {
+		t.Errorf("expected the item back at the top level; got %+v, %v", item, err)
+	}
+}
+
+// changingHistory updates the item it reads the history of, as if someone else changed it right
+// after the history was read.
+type changingHistory struct {
+	repository.TodoItem
+}
+
+func (r changingHistory) GetHistory(ctx context.Context, userId, id int) ([]todoListSber.Revision, error) {
+	history, err := r.TodoItem.GetHistory(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	title := "Changed meanwhile"
+	return history, r.TodoItem.Update(ctx, userId, id, todoListSber.UpdateItemInput{Title: &title})
+}
+
+func TestTodoItemServiceRevertConcurrentChange(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Report", Date: date})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	title := "Quarterly report"
+	if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{Title: &title}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	repos.Transactor = wrappedTransactions{Transactor: repos.Transactor, wrap: func(r repository.TodoItem) repository.TodoItem {
+		return changingHistory{TodoItem: r}
+	}}
+	var mismatch *todoListSber.PreconditionFailedError
+	if err := s.Revert(ctx, 1, id, 0); !errors.As(err, &mismatch) {
+		t.Fatalf("expected PreconditionFailedError; got %v", err)
+	}
+}
+
+func TestTodoItemServicePatch(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+	date := time.Date(2024, time.June, 5, 20, 0, 0, 0, time.UTC)
+
+	listId, err := repos.TodoList.Create(ctx, 1, todoListSber.TodoList{Title: "Work"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Report", Description: "Q2", Date: date, Priority: 2, ListId: &listId, Tags: []string{"work"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	merge := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: []byte(`{"description": null, "list_id": null, "priority": 1}`)}
+	if err := s.Patch(ctx, 1, id, merge); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	item, err := s.GetById(ctx, 1, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item.Description != "" || item.ListId != nil || item.Priority != 1 || item.Title != "Report" || len(item.Tags) != 1 || item.Version != 2 {
+		t.Errorf("expected the description and list cleared and the rest kept; got %+v", item)
+	}
+
+	jsonPatch := todoListSber.ItemPatch{Format: todoListSber.JSONPatch, Document: []byte(`[
+		{"op": "test", "path": "/title", "value": "Report"},
+		{"op": "add", "path": "/tags/-", "value": "urgent"},
+		{"op": "replace", "path": "/title", "value": "Quarterly report"}
+	]`)}
+	if err := s.Patch(ctx, 1, id, jsonPatch); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err = s.GetById(ctx, 1, id); err != nil || item.Title != "Quarterly report" || len(item.Tags) != 2 {
+		t.Errorf("expected the title replaced and a tag added; got %+v, %v", item, err)
+	}
+
+	var conflict *todoListSber.ConflictError
+	failedTest := todoListSber.ItemPatch{Format: todoListSber.JSONPatch, Document: []byte(`[{"op": "test", "path": "/title", "value": "Report"}]`)}
+	if err := s.Patch(ctx, 1, id, failedTest); !errors.As(err, &conflict) {
+		t.Errorf("expected ConflictError for a failed test; got %v", err)
+	}
+
+	var validation *todoListSber.ValidationError
+	for _, document := range []string{`{"title": ""}`, `{"date": null}`, `{"done": true}`, `{"priority": "high"}`, `[`} {
+		patch := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: []byte(document)}
+		if err := s.Patch(ctx, 1, id, patch); !errors.As(err, &validation) {
+			t.Errorf("expected ValidationError for %s; got %v", document, err)
+		}
+	}
+
+	var mismatch *todoListSber.PreconditionFailedError
+	stale := 1
+	versioned := todoListSber.ItemPatch{Format: todoListSber.MergePatch, Document: []byte(`{"is_done": true}`), Version: &stale}
+	if err := s.Patch(ctx, 1, id, versioned); !errors.As(err, &mismatch) {
+		t.Errorf("expected PreconditionFailedError for a stale version; got %v", err)
+	}
+
+	if err := s.Replace(ctx, 1, id, todoListSber.ItemFields{Title: "Replaced", Date: &date}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if item, err = s.GetById(ctx, 1, id); err != nil || item.Priority != todoListSber.DefaultPriority || len(item.Tags) != 0 || item.Description != "" {
+		t.Errorf("expected the fields left out reset; got %+v, %v", item, err)
+	}
+	if err := s.Replace(ctx, 1, id, todoListSber.ItemFields{Title: "No date"}); !errors.As(err, &validation) {
+		t.Errorf("expected ValidationError without a date; got %v", err)
+	}
+}
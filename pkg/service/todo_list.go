@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// TodoListService publishes events for the items that deleting a list deletes or moves out of it.
+type TodoListService struct {
+	repo     repository.TodoList
+	itemRepo repository.TodoItem
+	events   *EventService
+}
+
+func NewTodoListService(repo repository.TodoList, itemRepo repository.TodoItem, events *EventService) *TodoListService {
+	return &TodoListService{repo: repo, itemRepo: itemRepo, events: events}
+}
+func (s *TodoListService) Create(ctx context.Context, userId int, list todoListSber.TodoList) (int, error) {
+	if err := list.Validate(); err != nil {
+		return 0, err
+	}
+	return s.repo.Create(ctx, userId, list)
+}
+func (s *TodoListService) GetAll(ctx context.Context, userId int) ([]todoListSber.TodoList, error) {
+	return s.repo.GetAll(ctx, userId)
+}
+func (s *TodoListService) GetById(ctx context.Context, userId, id int) (todoListSber.TodoList, error) {
+	return s.repo.GetById(ctx, userId, id)
+}
+func (s *TodoListService) Delete(ctx context.Context, userId, id int, cascade bool) error {
+	page, err := s.itemRepo.GetAll(ctx, userId, todoListSber.TodoItemQuery{ListId: &id, SkipTotal: true})
+	if err != nil {
+		return err
+	}
+	if cascade {
+		deleted, err := s.subtrees(ctx, userId, page.Items)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, userId, id, cascade); err != nil {
+			return err
+		}
+		for _, item := range deleted {
+			s.events.Publish(ctx, userId, todoListSber.EventItemDeleted, item)
+		}
+		return nil
+	}
+	if err := s.repo.Delete(ctx, userId, id, cascade); err != nil {
+		return err
+	}
+	ids := make([]int, len(page.Items))
+	for i, item := range page.Items {
+		ids[i] = item.Id
+	}
+	s.events.publishUpdated(ctx, s.itemRepo, userId, ids)
+	return nil
+}
+func (s *TodoListService) Update(ctx context.Context, userId, id int, input todoListSber.UpdateListInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, userId, id, input)
+}
+
+// subtrees returns items together with their subtasks, which are deleted with them whatever list
+// they are in.
+func (s *TodoListService) subtrees(ctx context.Context, userId int, items []todoListSber.TodoItem) ([]todoListSber.TodoItem, error) {
+	var result []todoListSber.TodoItem
+	seen := make(map[int]bool)
+	for _, item := range items {
+		if seen[item.Id] {
+			continue
+		}
+		subtree, err := s.itemRepo.GetSubtree(ctx, userId, item.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, subtask := range subtree {
+			if !seen[subtask.Id] {
+				seen[subtask.Id] = true
+				result = append(result, subtask)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// webhookSecretBytes is the size of generated webhook secrets.
+const webhookSecretBytes = 32
+
+// WebhookService manages webhooks. Secrets are only returned by Create.
+type WebhookService struct {
+	repo repository.Webhook
+}
+
+func NewWebhookService(repo repository.Webhook) *WebhookService {
+	return &WebhookService{repo: repo}
+}
+
+// Create registers webhook and returns it with its id and secret.
+func (s *WebhookService) Create(ctx context.Context, userId int, webhook todoListSber.Webhook) (todoListSber.Webhook, error) {
+	if err := webhook.Validate(); err != nil {
+		return todoListSber.Webhook{}, err
+	}
+	if webhook.Secret == "" {
+		secret := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(secret); err != nil {
+			return todoListSber.Webhook{}, err
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+	webhook.CreatedAt = time.Now().UTC()
+	id, err := s.repo.Create(ctx, userId, webhook)
+	if err != nil {
+		return todoListSber.Webhook{}, err
+	}
+	webhook.Id = id
+	return webhook, nil
+}
+func (s *WebhookService) GetAll(ctx context.Context, userId int) ([]todoListSber.Webhook, error) {
+	webhooks, err := s.repo.GetAll(ctx, userId)
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, err
+}
+func (s *WebhookService) GetById(ctx context.Context, userId, id int) (todoListSber.Webhook, error) {
+	webhook, err := s.repo.GetById(ctx, userId, id)
+	webhook.Secret = ""
+	return webhook, err
+}
+func (s *WebhookService) Delete(ctx context.Context, userId, id int) error {
+	return s.repo.Delete(ctx, userId, id)
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first.
+func (s *WebhookService) GetDeliveries(ctx context.Context, userId, webhookId, limit, offset int) ([]todoListSber.WebhookDelivery, error) {
+	if limit <= 0 {
+		return nil, &todoListSber.ValidationError{Message: "limit must be positive"}
+	}
+	if offset < 0 {
+		return nil, &todoListSber.ValidationError{Message: "offset must not be negative"}
+	}
+	return s.repo.GetDeliveries(ctx, userId, webhookId, limit, offset)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestWebhookServiceSecret(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewWebhookService(repos.Webhook)
+
+	webhook, err := s.Create(ctx, 1, todoListSber.Webhook{URL: "https://example.com/hook"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(webhook.Secret) != 2*webhookSecretBytes {
+		t.Errorf("expected a generated secret; got %q", webhook.Secret)
+	}
+	stored, err := s.GetById(ctx, 1, webhook.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if stored.Secret != "" {
+		t.Errorf("expected the secret to be hidden; got %q", stored.Secret)
+	}
+
+	invalid := map[string]todoListSber.Webhook{
+		"Scheme":       {URL: "ftp://example.com/hook"},
+		"Short Secret": {URL: "https://example.com/hook", Secret: "secret"},
+		"Event":        {URL: "https://example.com/hook", Events: []string{"item.archived"}},
+	}
+	for name, webhook := range invalid {
+		t.Run(name, func(t *testing.T) {
+			var validation *todoListSber.ValidationError
+			if _, err := s.Create(ctx, 1, webhook); !errors.As(err, &validation) {
+				t.Errorf("expected ValidationError; got %v", err)
+			}
+		})
+	}
+}
+
+func TestTodoItemServiceEvents(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	s := NewTodoItemService(repos, NewEventService(repos.EventLog, repos.Webhook, 100))
+
+	webhookId, err := repos.Webhook.Create(ctx, 1, todoListSber.Webhook{URL: "https://example.com/hook", Secret: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	date := time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC)
+	id, err := s.Create(ctx, 1, todoListSber.TodoItem{Title: "Standup", Date: date, Recurrence: "FREQ=DAILY;COUNT=2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	done := true
+	if err := s.Update(ctx, 1, id, todoListSber.UpdateItemInput{IsDone: &done}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s.Delete(ctx, 1, id); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Another user's changes are not delivered.
+	if _, err := s.Create(ctx, 2, todoListSber.TodoItem{Title: "Other", Date: date}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	deliveries, err := repos.Webhook.GetDeliveries(ctx, 1, webhookId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []struct {
+		event  string
+		itemId int
+	}{
+		{todoListSber.EventItemDeleted, id},
+		{todoListSber.EventItemCreated, id + 1},
+		{todoListSber.EventItemCompleted, id},
+		{todoListSber.EventItemUpdated, id},
+		{todoListSber.EventItemCreated, id},
+	}
+	if len(deliveries) != len(expected) {
+		t.Fatalf("expected %d deliveries; got %+v", len(expected), deliveries)
+	}
+	for i, delivery := range deliveries {
+		var payload todoListSber.ItemEvent
+		if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if delivery.Event != expected[i].event || payload.Event != expected[i].event || payload.Item.Id != expected[i].itemId {
+			t.Errorf("expected %s of item %d; got %s of %+v", expected[i].event, expected[i].itemId, delivery.Event, payload.Item)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package trash purges the deleted items whose retention has run out in the background of the server process.
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"todo-list-sber/pkg/repository"
+)
+
+type Config struct {
+	// Retention is how long a deleted item stays in the trash.
+	Retention time.Duration
+	// Interval is how often the trash is looked through.
+	Interval time.Duration
+}
+
+// Purger deletes the items that have been in the trash for longer than the retention for good.
+// Purging is a single idempotent statement, so several instances can run it side by side.
+type Purger struct {
+	repo repository.TodoItem
+	cfg  Config
+	now  func() time.Time
+}
+
+func New(repo repository.TodoItem, cfg Config) *Purger {
+	return &Purger{repo: repo, cfg: cfg, now: time.Now}
+}
+
+// Run purges the trash on start and then every Interval until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		p.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick purges the items deleted before the retention. It returns the number purged.
+func (p *Purger) Tick(ctx context.Context) int {
+	purged, err := p.repo.Purge(ctx, p.now().Add(-p.cfg.Retention))
+	if err != nil {
+		slog.Error("error purging trash", "error", err)
+		return 0
+	}
+	if purged > 0 {
+		slog.Info("purged trash", "items", purged)
+	}
+	return purged
+}
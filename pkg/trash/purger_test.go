@@ -0,0 +1,48 @@
+package trash
+
+import (
+	"context"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestPurgerTick(t *testing.T) {
+	ctx := context.Background()
+	repos := repository.NewMemoryRepository()
+	now := time.Now()
+
+	parentId, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Parent", Date: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Subtask", Date: now, ParentId: &parentId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := repos.TodoItem.Create(ctx, 1, todoListSber.TodoItem{Title: "Kept", Date: now}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := repos.TodoItem.Delete(ctx, 1, parentId); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	p := New(repos.TodoItem, Config{Retention: time.Hour, Interval: time.Minute})
+	p.now = func() time.Time { return now.Add(30 * time.Minute) }
+	if n := p.Tick(ctx); n != 0 {
+		t.Fatalf("expected nothing to be purged within the retention; purged %d", n)
+	}
+
+	p.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if n := p.Tick(ctx); n != 2 {
+		t.Fatalf("expected the deleted item and its subtask to be purged; purged %d", n)
+	}
+	trash, err := repos.TodoItem.GetTrash(ctx, 1, 10, 0)
+	if err != nil || len(trash) != 0 {
+		t.Errorf("expected an empty trash; got %v, %v", trash, err)
+	}
+	page, err := repos.TodoItem.GetAll(ctx, 1, todoListSber.TodoItemQuery{})
+	if err != nil || len(page.Items) != 1 || page.Items[0].Title != "Kept" {
+		t.Errorf("expected the other item to be kept; got %v, %v", page.Items, err)
+	}
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// Ranges of IPv4 addresses that are neither private nor public: "this network" of RFC 791 and the
+// carrier-grade NAT range of RFC 6598.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// newClient returns the client deliveries are sent with. Webhook URLs are chosen by users, so unless
+// allowPrivate is set the client refuses to connect to anything but public addresses. The check is
+// made on the address actually dialed, after name resolution, so a name resolving to an internal
+// address is refused as well. Proxies are not used and redirects are not followed, as either would
+// send the request somewhere else than the address checked: a redirect fails the delivery like
+// any other non-2xx response.
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			if !isPublic(addr) {
+				return fmt.Errorf("webhook address %s is not public", addr)
+			}
+			return nil
+		}
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublic reports whether addr is a unicast address outside the loopback, private, link-local
+// and other internal ranges.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
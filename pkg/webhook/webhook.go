@@ -0,0 +1,153 @@
+// Package webhook sends queued item events to the webhooks of their owners.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+// Headers of a delivery request.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign returns the signature header value of body: "sha256=" followed by the hex HMAC-SHA256 of
+// body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Config struct {
+	PollInterval time.Duration
+	// Lease is how long a delivery is reserved for one attempt, like the reminder lease.
+	Lease     time.Duration
+	BatchSize int
+	// Timeout bounds one delivery request.
+	Timeout time.Duration
+	// MaxAttempts is how many times a delivery is attempted before it fails. The n-th retry waits
+	// BackoffBase * 2^(n-1), but never longer than BackoffMax.
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// AllowPrivateNetworks lets deliveries reach loopback, private and link-local addresses, which
+	// are refused by default.
+	AllowPrivateNetworks bool
+}
+
+// Dispatcher attempts pending deliveries and records the outcome of every attempt in the delivery log.
+// A delivery succeeds on a 2xx response; anything else is retried with exponential backoff.
+type Dispatcher struct {
+	repo   repository.Webhook
+	client *http.Client
+	cfg    Config
+	now    func() time.Time
+}
+
+func NewDispatcher(repo repository.Webhook, cfg Config) *Dispatcher {
+	return &Dispatcher{repo: repo, client: newClient(cfg.Timeout, cfg.AllowPrivateNetworks), cfg: cfg, now: time.Now}
+}
+
+// Run attempts pending deliveries every PollInterval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for d.Tick(ctx) == d.cfg.BatchSize && ctx.Err() == nil {
+			// Work off a backlog without waiting, as the reminder scheduler does.
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick claims one batch of pending deliveries and attempts them. It returns the number claimed.
+func (d *Dispatcher) Tick(ctx context.Context) int {
+	pending, err := d.repo.ClaimDeliveries(ctx, d.now(), d.cfg.Lease, d.cfg.BatchSize)
+	if err != nil {
+		slog.Error("error claiming webhook deliveries", "error", err)
+		return 0
+	}
+	for _, delivery := range pending {
+		if err := d.repo.UpdateDelivery(ctx, d.attempt(ctx, delivery)); err != nil {
+			slog.Error("error recording webhook delivery", "delivery_id", delivery.Id, "error", err)
+		}
+	}
+	return len(pending)
+}
+
+// attempt sends delivery once and returns it updated with the outcome.
+func (d *Dispatcher) attempt(ctx context.Context, pending todoListSber.PendingDelivery) todoListSber.WebhookDelivery {
+	delivery := pending.WebhookDelivery
+	status, err := d.send(ctx, pending)
+	now := d.now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	delivery.Error = ""
+	delivery.NextAttemptAt = nil
+	switch {
+	case err == nil:
+		delivery.Status = todoListSber.DeliverySucceeded
+		return delivery
+	case delivery.Attempts >= d.cfg.MaxAttempts:
+		delivery.Status = todoListSber.DeliveryFailed
+	default:
+		next := now.Add(d.backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+	}
+	delivery.Error = err.Error()
+	slog.Warn("error delivering webhook", "delivery_id", delivery.Id, "webhook_id", delivery.WebhookId,
+		"attempts", delivery.Attempts, "error", err)
+	return delivery
+}
+
+// send posts the delivery and returns the response status, or 0 when there was no response.
+func (d *Dispatcher) send(ctx context.Context, delivery todoListSber.PendingDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.Id))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before the retry following attempts failed attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.BackoffBase
+	for i := 1; i < attempts && wait < d.cfg.BackoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, d.cfg.BackoffMax)
+}
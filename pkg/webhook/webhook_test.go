@@ -0,0 +1,196 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+	todoListSber "todo-list-sber"
+	"todo-list-sber/pkg/repository"
+)
+
+func TestSign(t *testing.T) {
+	expected := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got := Sign("key", []byte("The quick brown fox jumps over the lazy dog")); got != expected {
+		t.Errorf("expected %s; got %s", expected, got)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewWebhookMemory()
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+	statuses := []int{http.StatusInternalServerError, http.StatusOK}
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.WriteHeader(statuses[0])
+		statuses = statuses[1:]
+	}))
+	defer server.Close()
+
+	webhookId, err := repo.Create(ctx, 1, todoListSber.Webhook{URL: server.URL, Secret: "secret", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	payload := []byte(`{"event":"item.created"}`)
+	if err := repo.Enqueue(ctx, 1, todoListSber.EventItemCreated, payload, now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := NewDispatcher(repo, Config{Lease: time.Minute, BatchSize: 10, Timeout: time.Second, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour, AllowPrivateNetworks: true})
+	d.now = func() time.Time { return now }
+	d.Tick(ctx)
+	if len(requests) != 1 {
+		t.Fatalf("expected one request; got %d", len(requests))
+	}
+	header := requests[0].Header
+	if header.Get(HeaderSignature) != Sign("secret", payload) || header.Get(HeaderEvent) != todoListSber.EventItemCreated || header.Get(HeaderDelivery) != "1" {
+		t.Errorf("unexpected headers %v", header)
+	}
+
+	deliveries, err := repo.GetDeliveries(ctx, 1, webhookId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	failed := deliveries[0]
+	if failed.Status != todoListSber.DeliveryPending || failed.Attempts != 1 || *failed.ResponseStatus != 500 ||
+		!failed.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected a retry in a minute; got %+v", failed)
+	}
+
+	d.now = func() time.Time { return now.Add(time.Minute) }
+	d.Tick(ctx)
+	deliveries, err = repo.GetDeliveries(ctx, 1, webhookId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if delivered := deliveries[0]; delivered.Status != todoListSber.DeliverySucceeded || delivered.Attempts != 2 || delivered.Error != "" {
+		t.Errorf("expected the retry to succeed; got %+v", delivered)
+	}
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewWebhookMemory()
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+
+	// Nothing listens on the URL of a closed server.
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	webhookId, err := repo.Create(ctx, 1, todoListSber.Webhook{URL: server.URL, Secret: "secret", CreatedAt: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := repo.Enqueue(ctx, 1, todoListSber.EventItemDeleted, []byte(`{}`), now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := NewDispatcher(repo, Config{Lease: time.Minute, BatchSize: 10, Timeout: time.Second, MaxAttempts: 4, BackoffBase: time.Minute, BackoffMax: 3 * time.Minute, AllowPrivateNetworks: true})
+	var waits []time.Duration
+	for attempt := 0; attempt < 5; attempt++ {
+		d.now = func() time.Time { return now }
+		d.Tick(ctx)
+		deliveries, err := repo.GetDeliveries(ctx, 1, webhookId, 10, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if deliveries[0].NextAttemptAt == nil {
+			break
+		}
+		waits = append(waits, deliveries[0].NextAttemptAt.Sub(now))
+		now = *deliveries[0].NextAttemptAt
+	}
+
+	deliveries, err := repo.GetDeliveries(ctx, 1, webhookId, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if failed := deliveries[0]; failed.Status != todoListSber.DeliveryFailed || failed.Attempts != 4 || failed.ResponseStatus != nil || failed.Error == "" {
+		t.Errorf("expected the delivery to fail after 4 attempts; got %+v", failed)
+	}
+	if expected := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}; len(waits) != 3 || waits[0] != expected[0] || waits[1] != expected[1] || waits[2] != expected[2] {
+		t.Errorf("expected backoff %v; got %v", expected, waits)
+	}
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	redirects := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL, http.StatusFound)
+	}))
+	defer redirects.Close()
+
+	tests := []struct {
+		name         string
+		url          string
+		allowPrivate bool
+		redirect     bool
+	}{
+		{name: "Loopback", url: server.URL},
+		{name: "Redirect", url: redirects.URL, allowPrivate: true, redirect: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := repository.NewWebhookMemory()
+			webhookId, err := repo.Create(ctx, 1, todoListSber.Webhook{URL: test.url, Secret: "secret", CreatedAt: now})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := repo.Enqueue(ctx, 1, todoListSber.EventItemCreated, []byte(`{}`), now); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := NewDispatcher(repo, Config{Lease: time.Minute, BatchSize: 10, Timeout: time.Second, MaxAttempts: 3, BackoffBase: time.Minute, BackoffMax: time.Hour, AllowPrivateNetworks: test.allowPrivate})
+			d.now = func() time.Time { return now }
+			d.Tick(ctx)
+
+			deliveries, err := repo.GetDeliveries(ctx, 1, webhookId, 10, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if delivery := deliveries[0]; delivery.Status != todoListSber.DeliveryPending || delivery.Error == "" {
+				t.Errorf("expected a failed attempt; got %+v", delivery)
+			}
+			if test.redirect && (deliveries[0].ResponseStatus == nil || *deliveries[0].ResponseStatus != http.StatusFound) {
+				t.Errorf("expected the redirect recorded, not followed; got %+v", deliveries[0])
+			}
+			if requested {
+				t.Errorf("expected no request to reach %s", server.URL)
+			}
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	for address, expected := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::1":     true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false,
+		"100.64.0.1":             false,
+		"0.0.0.0":                false,
+		"::1":                    false,
+		"fd00::1":                false,
+		"fe80::1":                false,
+		"::ffff:127.0.0.1":       false,
+		"::ffff:169.254.169.254": false,
+	} {
+		if got := isPublic(netip.MustParseAddr(address)); got != expected {
+			t.Errorf("expected isPublic(%s) = %v; got %v", address, expected, got)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package todo_list_sber
+
+import "time"
+
+// Sort fields accepted by TodoItemQuery. Ties are broken by the next fields of the
+// default order and finally by id, so that pages are stable.
+const (
+	SortByPriority = "priority"
+	SortByDate     = "date"
+	SortByTitle    = "title"
+	SortById       = "id"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 100
+)
+
+// TodoItemQuery selects a page of the items of a user. Zero values do not filter:
+// IsDone nil matches both statuses, From and To are inclusive days compared on the
+// wall clock of the item date, Text is a case-insensitive substring of the title or
+// description. A zero Limit returns every matching item.
+//
+// Ready matches the undone items whose blockers are all done, whatever IsDone is.
+//
+// After turns the query into a keyset page: only items strictly after the given (date, id)
+// position in the requested direction are returned, which needs Sort to be SortByDate.
+type TodoItemQuery struct {
+	IsDone *bool
+	From   *time.Time
+	To     *time.Time
+	Text   string
+	Tags   TagFilter
+	ListId *int
+	Ready  bool
+	Sort   string
+	Desc   bool
+	Limit  int
+	Offset int
+	After  *ItemCursor
+	// SkipTotal leaves TodoItemPage.Total zero, sparing the count keyset pages do not need.
+	SkipTotal bool
+}
+
+func (q TodoItemQuery) Validate() error {
+	switch q.Sort {
+	case "", SortByPriority, SortByDate, SortByTitle, SortById:
+	default:
+		return &ValidationError{Message: "sort must be one of priority, date, title, id"}
+	}
+	if q.After != nil && q.Sort != SortByDate {
+		return &ValidationError{Message: "cursor pagination requires sorting by date"}
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return &ValidationError{Message: "limit and offset must not be negative"}
+	}
+	if q.From != nil && q.To != nil && q.To.Before(*q.From) {
+		return &ValidationError{Message: "to must not be before from"}
+	}
+	return nil
+}
+
+// TodoItemPage is one page of a TodoItemQuery together with the number of items matching it.
+type TodoItemPage struct {
+	Items []TodoItem
+	Total int
+}
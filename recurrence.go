@@ -0,0 +1,79 @@
+package todo_list_sber
+
+import (
+	"time"
+	"todo-list-sber/pkg/rrule"
+)
+
+func validateRecurrence(recurrence string) error {
+	if recurrence == "" {
+		return nil
+	}
+	if _, err := rrule.Parse(recurrence); err != nil {
+		return &ValidationError{Message: "invalid recurrence: " + err.Error()}
+	}
+	return nil
+}
+
+func validateTimezone(timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return &ValidationError{Message: "unknown timezone " + timezone}
+	}
+	return nil
+}
+
+// Location returns the timezone the recurrence of the item is expanded in.
+func (i TodoItem) Location() *time.Location {
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Occurrences returns at most limit occurrences of the item within [from, to]. An item without
+// a recurrence has a single occurrence at its date. A zero limit returns every occurrence.
+func (i TodoItem) Occurrences(from, to time.Time, limit int) ([]time.Time, error) {
+	dtstart := i.Date.In(i.Location())
+	if i.Recurrence == "" {
+		if dtstart.Before(from) || dtstart.After(to) {
+			return nil, nil
+		}
+		return []time.Time{dtstart}, nil
+	}
+	rule, err := rrule.Parse(i.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	return rule.Between(dtstart, from, to, limit), nil
+}
+
+// NextOccurrence returns the undone item that follows i in its series, or false when i does not
+// recur or was the last occurrence. The rule of the next item describes the rest of the series
+// from its own date, so a COUNT is decreased by one.
+func (i TodoItem) NextOccurrence() (TodoItem, bool, error) {
+	if i.Recurrence == "" {
+		return TodoItem{}, false, nil
+	}
+	rule, err := rrule.Parse(i.Recurrence)
+	if err != nil {
+		return TodoItem{}, false, err
+	}
+	dtstart := i.Date.In(i.Location())
+	date, ok := rule.After(dtstart, dtstart)
+	if !ok {
+		return TodoItem{}, false, nil
+	}
+	if rule.Count > 0 {
+		rule.Count--
+	}
+
+	next := i
+	next.Id = 0
+	next.NextId = nil
+	next.IsDone = false
+	next.Date = date.UTC()
+	next.Recurrence = rule.String()
+	next.Tags = append([]string(nil), i.Tags...)
+	return next, true, nil
+}
@@ -0,0 +1,44 @@
+package todo_list_sber
+
+import "time"
+
+// Reminder is a notification about an item, due either at an absolute time At or BeforeMinutes
+// before the date of the item. RemindAt is when it fires; for an offset reminder it follows the
+// date of the item. SentAt is set once the reminder has been delivered.
+type Reminder struct {
+	Id            int        `json:"id" db:"id"`
+	ItemId        int        `json:"item_id" db:"item_id"`
+	At            *time.Time `json:"at,omitempty" db:"at"`
+	BeforeMinutes *int       `json:"before_minutes,omitempty" db:"before_minutes"`
+	RemindAt      time.Time  `json:"remind_at" db:"remind_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+}
+
+func (r Reminder) Validate() error {
+	if (r.At == nil) == (r.BeforeMinutes == nil) {
+		return &ValidationError{Message: "reminder must have exactly one of at and before_minutes"}
+	}
+	if r.BeforeMinutes != nil && *r.BeforeMinutes < 0 {
+		return &ValidationError{Message: "before_minutes must not be negative"}
+	}
+	return nil
+}
+
+// Schedule returns the time the reminder fires for an item dated date.
+func (r Reminder) Schedule(date time.Time) time.Time {
+	if r.At != nil {
+		return *r.At
+	}
+	return date.Add(-time.Duration(*r.BeforeMinutes) * time.Minute)
+}
+
+// DueReminder is a reminder claimed for delivery together with the item it is about.
+type DueReminder struct {
+	Reminder
+	UserId int
+	Item   TodoItem
+}
+
+func ErrReminderNotFound(id int) error {
+	return &NotFoundError{Entity: "reminder", Id: id}
+}
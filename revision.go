@@ -0,0 +1,140 @@
+package todo_list_sber
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Revision is one recorded change of a todo item, by an update or any other request changing its
+// fields: the fields it changed with their old and new values, the user who made it and when. The
+// revisions of an item are numbered from 1.
+type Revision struct {
+	Revision  int           `json:"revision" db:"revision"`
+	ItemId    int           `json:"item_id" db:"item_id"`
+	ActorId   int           `json:"actor_id" db:"actor_id"`
+	Changes   []FieldChange `json:"changes" db:"-"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+}
+
+// FieldChange holds the JSON values of a field of an item before and after a change.
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old" swaggertype:"object"`
+	New   json.RawMessage `json:"new" swaggertype:"object"`
+}
+
+type itemField struct {
+	name  string
+	value interface{}
+}
+
+// itemFields returns the fields of item recorded in its revisions: those of UpdateItemInput, in
+// its order, and the parent set by a move. Dates are taken in UTC and tags in sorted order.
+func itemFields(item TodoItem) []itemField {
+	return []itemField{
+		{"title", item.Title},
+		{"description", item.Description},
+		{"is_done", item.IsDone},
+		{"date", item.Date.UTC()},
+		{"priority", item.Priority},
+		{"list_id", item.ListId},
+		{"tags", sortedTags(item.Tags)},
+		{"recurrence", item.Recurrence},
+		{"timezone", item.Timezone},
+		{"parent_id", item.ParentId},
+	}
+}
+
+// DiffItems returns the fields recorded in revisions which differ between before and after, in the
+// order of itemFields.
+func DiffItems(before, after TodoItem) ([]FieldChange, error) {
+	oldFields, newFields := itemFields(before), itemFields(after)
+	var changes []FieldChange
+	for i := range oldFields {
+		old, err := json.Marshal(oldFields[i].value)
+		if err != nil {
+			return nil, err
+		}
+		new, err := json.Marshal(newFields[i].value)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(old, new) {
+			changes = append(changes, FieldChange{Field: oldFields[i].name, Old: old, New: new})
+		}
+	}
+	return changes, nil
+}
+
+// ItemRevert brings an item back to an earlier state: Input sets its fields and Move puts it back
+// under its former parent. Either is nil when there is nothing for it to change.
+type ItemRevert struct {
+	Input *UpdateItemInput
+	Move  *MoveItemInput
+}
+
+// PlanRevert works out how to bring item back to its state right after revision, undoing the later
+// revisions, given the revisions of the item in any order. Revision 0 stands for the item as it was
+// created. Only the fields that differ from item are changed.
+func PlanRevert(item TodoItem, revisions []Revision, revision int) (ItemRevert, error) {
+	sorted := append([]Revision(nil), revisions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Revision > sorted[j].Revision })
+
+	// Going from the latest revision back, the value a field had right after revision is the old
+	// value of the first revision after it that changed the field.
+	values := make(map[string]json.RawMessage)
+	for _, r := range sorted {
+		if r.Revision <= revision {
+			break
+		}
+		for _, change := range r.Changes {
+			values[change.Field] = change.Old
+		}
+	}
+	for _, field := range itemFields(item) {
+		value, ok := values[field.name]
+		if !ok {
+			continue
+		}
+		current, err := json.Marshal(field.value)
+		if err != nil {
+			return ItemRevert{}, err
+		}
+		if bytes.Equal(value, current) {
+			delete(values, field.name)
+		}
+	}
+
+	var plan ItemRevert
+	if parent, ok := values["parent_id"]; ok {
+		plan.Move = &MoveItemInput{}
+		if err := json.Unmarshal(parent, &plan.Move.ParentId); err != nil {
+			return plan, err
+		}
+		delete(values, "parent_id")
+	}
+	if len(values) == 0 {
+		return plan, nil
+	}
+	plan.Input = &UpdateItemInput{}
+	body, err := json.Marshal(values)
+	if err != nil {
+		return plan, err
+	}
+	if err := json.Unmarshal(body, plan.Input); err != nil {
+		return plan, err
+	}
+	// A null list_id unmarshals the same as a missing one, so taking the item out of its list is explicit.
+	if old, ok := values["list_id"]; ok && string(old) == "null" {
+		plan.Input.ClearList = true
+	}
+	return plan, nil
+}
+
+func sortedTags(tags []string) []string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,42 @@
+package todo_list_sber
+
+import "strings"
+
+// Text search configurations accepted by SearchQuery.
+const (
+	SearchLanguageRussian = "russian"
+	SearchLanguageEnglish = "english"
+)
+
+// SearchQuery is a full-text search over the title and description of the items of a user.
+// Text uses the web search syntax: words, "quoted phrases", OR and -excluded words.
+// An empty Language matches the stems of both languages. A zero Limit returns every match.
+type SearchQuery struct {
+	Text     string
+	Language string
+	Limit    int
+	Offset   int
+}
+
+func (q SearchQuery) Validate() error {
+	if strings.TrimSpace(q.Text) == "" {
+		return &ValidationError{Message: "search query must not be empty"}
+	}
+	switch q.Language {
+	case "", SearchLanguageRussian, SearchLanguageEnglish:
+	default:
+		return &ValidationError{Message: "language must be one of russian, english"}
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return &ValidationError{Message: "limit and offset must not be negative"}
+	}
+	return nil
+}
+
+// SearchResult is an item matching a SearchQuery. Snippet is the matching part of the title and
+// description with the matched words wrapped in <b></b>. Results are ordered by Rank, highest first.
+type SearchResult struct {
+	TodoItem
+	Rank    float64 `json:"rank" db:"rank"`
+	Snippet string  `json:"snippet" db:"snippet"`
+}
@@ -3,23 +3,36 @@ package todo_list_sber
 import (
 	"context"
 	"net/http"
-	"time"
+	"todo-list-sber/pkg/config"
 )
 
 type Server struct {
 	httpServer *http.Server
 }
 
-func (s *Server) Run(port string, handler http.Handler) error {
-	s.httpServer = &http.Server{
-		Addr:           ":" + port,
-		Handler:        handler,
-		MaxHeaderBytes: 1 << 20,
-		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
+func NewServer(cfg config.HTTPConfig, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:           ":" + cfg.Port,
+			Handler:        handler,
+			MaxHeaderBytes: cfg.MaxHeaderBytes,
+			ReadTimeout:    cfg.ReadTimeout,
+			WriteTimeout:   cfg.WriteTimeout,
+		},
 	}
+}
+
+// Run serves HTTP until Shutdown is called, in which case it returns http.ErrServerClosed.
+func (s *Server) Run() error {
 	return s.httpServer.ListenAndServe()
 }
+
+// RegisterOnShutdown registers f to be called when Shutdown starts, e.g. to end long-lived responses.
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.httpServer.RegisterOnShutdown(f)
+}
+
+// Shutdown stops accepting new connections and waits for in-flight requests to finish or ctx to expire.
 func (s *Server) Shutdown(ctx context.Context) error {
 	return s.httpServer.Shutdown(ctx)
 }
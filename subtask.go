@@ -0,0 +1,54 @@
+package todo_list_sber
+
+// SubtaskCounts tells how many of the subtasks of an item are done.
+type SubtaskCounts struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// TodoItemNode is an item together with its direct subtasks.
+type TodoItemNode struct {
+	TodoItem
+	Children []TodoItemNode `json:"children"`
+}
+
+// MoveItemInput puts an item under ParentId, or makes it a top-level item when ParentId is null.
+type MoveItemInput struct {
+	ParentId *int `json:"parent_id"`
+	// Version makes the move fail unless the item is at that version.
+	Version *int `json:"-"`
+}
+
+// BuildTree arranges subtree, the root item followed by its subtasks at any depth, into a tree
+// and fills in the subtask counts of every node that has subtasks. Children keep the order of
+// subtree.
+func BuildTree(subtree []TodoItem) TodoItemNode {
+	children := make(map[int][]TodoItem)
+	for _, item := range subtree[1:] {
+		if item.ParentId != nil {
+			children[*item.ParentId] = append(children[*item.ParentId], item)
+		}
+	}
+	var build func(item TodoItem) TodoItemNode
+	build = func(item TodoItem) TodoItemNode {
+		node := TodoItemNode{TodoItem: item, Children: []TodoItemNode{}}
+		counts := SubtaskCounts{}
+		for _, child := range children[item.Id] {
+			childNode := build(child)
+			counts.Total++
+			if child.IsDone {
+				counts.Completed++
+			}
+			if childNode.Subtasks != nil {
+				counts.Total += childNode.Subtasks.Total
+				counts.Completed += childNode.Subtasks.Completed
+			}
+			node.Children = append(node.Children, childNode)
+		}
+		if counts.Total > 0 {
+			node.Subtasks = &counts
+		}
+		return node
+	}
+	return build(subtree[0])
+}
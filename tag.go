@@ -0,0 +1,42 @@
+package todo_list_sber
+
+import "unicode/utf8"
+
+// maxTagNameLength matches the VARCHAR(64) tags.name column.
+const maxTagNameLength = 64
+
+// Tag labels todo items across lists. Tag names are unique per user.
+type Tag struct {
+	Id   int    `json:"id" db:"id"`
+	Name string `json:"name" db:"name" binding:"required"`
+}
+
+func (t Tag) Validate() error {
+	return validateTagName(t.Name)
+}
+
+// TagFilter restricts a listing to items carrying the given tags: any of them by default,
+// or all of them when MatchAll is set. An empty filter matches every item.
+type TagFilter struct {
+	Tags     []string
+	MatchAll bool
+}
+
+func validateTagName(name string) error {
+	if name == "" {
+		return &ValidationError{Message: "tag name must not be empty"}
+	}
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return &ValidationError{Message: "tag name must not be longer than 64 characters"}
+	}
+	return nil
+}
+
+func validateTagNames(names []string) error {
+	for _, name := range names {
+		if err := validateTagName(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
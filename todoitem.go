@@ -1,6 +1,20 @@
 package todo_list_sber
 
-import "time"
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// maxTitleLength matches the VARCHAR(255) todo_items.title column.
+const maxTitleLength = 255
+
+// Priorities range from 1, the most urgent, to 4. Items created without a priority get
+// DefaultPriority, which is also what the migration assigns to existing rows.
+const (
+	HighestPriority = 1
+	LowestPriority  = 4
+	DefaultPriority = LowestPriority
+)
 
 type TodoItem struct {
 	Id          int       `json:"id" db:"id"`
@@ -8,10 +22,111 @@ type TodoItem struct {
 	Description string    `json:"description" db:"description"`
 	Date        time.Time `json:"date" db:"date" binding:"required"`
 	IsDone      bool      `json:"is_done" db:"is_done"`
+	Priority    int       `json:"priority" db:"priority"`
+	ListId      *int      `json:"list_id,omitempty" db:"list_id"`
+	// ParentId makes the item a subtask of another item of the same user.
+	ParentId *int     `json:"parent_id,omitempty" db:"parent_id"`
+	Tags     []string `json:"tags,omitempty" db:"-"`
+	// Recurrence is an RFC 5545 RRULE such as "FREQ=WEEKLY;BYDAY=MO,WE" with Date as the first
+	// occurrence. It is expanded in Timezone, an IANA name that defaults to UTC.
+	Recurrence string `json:"recurrence,omitempty" db:"recurrence"`
+	Timezone   string `json:"timezone,omitempty" db:"timezone"`
+	// Subtasks counts the subtasks of the item at any depth. It is only filled in responses
+	// about a single item and its children, and left out for items without subtasks.
+	Subtasks *SubtaskCounts `json:"subtasks,omitempty" db:"-"`
+	// NextId is the occurrence created when this occurrence of a recurring item was marked done.
+	NextId *int `json:"next_id,omitempty" db:"next_id"`
+	// DeletedAt is set while the item is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// Version starts at 1 and grows with every change its history records, see Revision. Moving the
+	// item to the trash and back or changing its subtasks leaves it as it is.
+	Version int `json:"version,omitempty" db:"version"`
+}
+
+func (i TodoItem) Validate() error {
+	if err := validateTitle(i.Title); err != nil {
+		return err
+	}
+	if i.Priority != 0 {
+		if err := validatePriority(i.Priority); err != nil {
+			return err
+		}
+	}
+	if err := validateRecurrence(i.Recurrence); err != nil {
+		return err
+	}
+	if err := validateTimezone(i.Timezone); err != nil {
+		return err
+	}
+	return validateTagNames(i.Tags)
 }
+
 type UpdateItemInput struct {
 	Title       *string    `json:"title"`
 	Description *string    `json:"description"`
 	IsDone      *bool      `json:"is_done"`
 	Date        *time.Time `json:"date"`
+	Priority    *int       `json:"priority"`
+	ListId      *int       `json:"list_id"`
+	// ClearList takes the item out of its list; it is ignored when ListId is set.
+	ClearList bool `json:"-"`
+	// NextId links the item to the next occurrence of its series, see TodoItem.NextId.
+	NextId *int `json:"-"`
+	// Tags replaces the tags of the item; tags that do not exist yet are created.
+	Tags *[]string `json:"tags"`
+	// Recurrence and Timezone set to "" make the item a one-off and reset the timezone to UTC.
+	Recurrence *string `json:"recurrence"`
+	Timezone   *string `json:"timezone"`
+	// Cascade marks the undone subtasks of the item done as well when IsDone is set to true.
+	Cascade bool `json:"-"`
+	// Version, when set, makes the update fail with ErrVersionMismatch unless the item is at that version.
+	Version *int `json:"-"`
+}
+
+func (i UpdateItemInput) Validate() error {
+	if i.Title == nil && i.Description == nil && i.IsDone == nil && i.Date == nil && i.Priority == nil && i.ListId == nil && !i.ClearList &&
+		i.Tags == nil && i.Recurrence == nil && i.Timezone == nil {
+		return &ValidationError{Message: "update structure has no values"}
+	}
+	if i.Title != nil {
+		if err := validateTitle(*i.Title); err != nil {
+			return err
+		}
+	}
+	if i.Priority != nil {
+		if err := validatePriority(*i.Priority); err != nil {
+			return err
+		}
+	}
+	if i.Recurrence != nil {
+		if err := validateRecurrence(*i.Recurrence); err != nil {
+			return err
+		}
+	}
+	if i.Timezone != nil {
+		if err := validateTimezone(*i.Timezone); err != nil {
+			return err
+		}
+	}
+	if i.Tags != nil {
+		return validateTagNames(*i.Tags)
+	}
+	return nil
+}
+
+func validateTitle(title string) error {
+	if title == "" {
+		return &ValidationError{Message: "title must not be empty"}
+	}
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return &ValidationError{Message: "title must not be longer than 255 characters"}
+	}
+	return nil
+}
+
+func validatePriority(priority int) error {
+	if priority < HighestPriority || priority > LowestPriority {
+		return &ValidationError{Message: "priority must be between 1 and 4"}
+	}
+	return nil
 }
@@ -0,0 +1,27 @@
+package todo_list_sber
+
+// TodoList groups the todo items of a user, e.g. work, personal or project tasks.
+type TodoList struct {
+	Id          int    `json:"id" db:"id"`
+	Title       string `json:"title" db:"title" binding:"required"`
+	Description string `json:"description" db:"description"`
+}
+
+func (l TodoList) Validate() error {
+	return validateTitle(l.Title)
+}
+
+type UpdateListInput struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+}
+
+func (i UpdateListInput) Validate() error {
+	if i.Title == nil && i.Description == nil {
+		return &ValidationError{Message: "update structure has no values"}
+	}
+	if i.Title != nil {
+		return validateTitle(*i.Title)
+	}
+	return nil
+}
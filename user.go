@@ -0,0 +1,31 @@
+package todo_list_sber
+
+import "unicode/utf8"
+
+const minPasswordLength = 8
+
+// maxPasswordLength is the longest password in bytes bcrypt can hash.
+const maxPasswordLength = 72
+
+type User struct {
+	Id           int    `json:"-" db:"id"`
+	Username     string `json:"username" db:"username" binding:"required"`
+	Password     string `json:"password" binding:"required"`
+	PasswordHash string `json:"-" db:"password_hash"`
+}
+
+func (u User) Validate() error {
+	if u.Username == "" {
+		return &ValidationError{Message: "username must not be empty"}
+	}
+	if utf8.RuneCountInString(u.Username) > 255 {
+		return &ValidationError{Message: "username must not be longer than 255 characters"}
+	}
+	if utf8.RuneCountInString(u.Password) < minPasswordLength {
+		return &ValidationError{Message: "password must be at least 8 characters long"}
+	}
+	if len(u.Password) > maxPasswordLength {
+		return &ValidationError{Message: "password must not be longer than 72 bytes"}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package todo_list_sber
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Lifecycle events of items delivered to webhooks.
+const (
+	EventItemCreated   = "item.created"
+	EventItemUpdated   = "item.updated"
+	EventItemCompleted = "item.completed"
+	EventItemDeleted   = "item.deleted"
+	EventItemRestored  = "item.restored"
+)
+
+// MinWebhookSecretLength is the shortest secret accepted from the client; generated secrets are longer.
+const MinWebhookSecretLength = 16
+
+var itemEvents = []string{EventItemCreated, EventItemUpdated, EventItemCompleted, EventItemDeleted, EventItemRestored}
+
+// Webhook is an endpoint receiving the item events of its owner. An empty Events subscribes to every
+// event. Secret signs the payloads; it is generated unless given and only shown on creation.
+type Webhook struct {
+	Id        int       `json:"id" db:"id"`
+	URL       string    `json:"url" db:"url" binding:"required"`
+	Events    []string  `json:"events" db:"-"`
+	Secret    string    `json:"secret,omitempty" db:"secret"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+func (w Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &ValidationError{Message: "url must be an http or https URL"}
+	}
+	if w.Secret != "" && len(w.Secret) < MinWebhookSecretLength {
+		return &ValidationError{Message: fmt.Sprintf("secret must be at least %d characters long", MinWebhookSecretLength)}
+	}
+	for _, event := range w.Events {
+		if !slices.Contains(itemEvents, event) {
+			return &ValidationError{Message: "unknown event " + event}
+		}
+	}
+	return nil
+}
+
+// Subscribes reports whether the webhook receives event.
+func (w Webhook) Subscribes(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// Delivery statuses. A pending delivery with attempts is waiting to be retried; a delivery fails
+// once its attempts are exhausted.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event sent to a webhook together with the outcome of its last attempt.
+type WebhookDelivery struct {
+	Id             int             `json:"id" db:"id"`
+	WebhookId      int             `json:"webhook_id" db:"webhook_id"`
+	Event          string          `json:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" db:"-" swaggertype:"object"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	Error          string          `json:"error,omitempty" db:"error"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// PendingDelivery is a delivery claimed for an attempt together with where and how to send it.
+type PendingDelivery struct {
+	WebhookDelivery
+	URL    string
+	Secret string
+}
+
+// ItemEvent is the payload of the item events.
+type ItemEvent struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Item      TodoItem  `json:"item"`
+}
+
+func ErrWebhookNotFound(id int) error {
+	return &NotFoundError{Entity: "webhook", Id: id}
+}
+
+func ErrWebhookDeliveryNotFound(id int) error {
+	return &NotFoundError{Entity: "webhook delivery", Id: id}
+}